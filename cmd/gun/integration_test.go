@@ -93,6 +93,249 @@ func TestAutoModeFallbackOnDynamicName(t *testing.T) {
 	mustContain(t, out, "RUN:Alpha/dyn")
 }
 
+func TestAutoModeRunsLocalVariableName(t *testing.T) {
+	file := testutil.FixtureFile(t)
+	line := testutil.MarkerLine(t, file, "local_var")
+	out, err := runGun(t, file, strconv.Itoa(line), "--", "-v")
+	if err != nil {
+		t.Fatalf("gun auto local variable failed: %v\n%s", err, out)
+	}
+	mustContain(t, out, "RUN:Alpha/local")
+	mustNotContain(t, out, "RUN:Alpha/dyn")
+}
+
+func TestLeafRunsUnrolledLoop(t *testing.T) {
+	file := testutil.FixturePath(t, "sample", "loops_test.go")
+	line := testutil.MarkerLine(t, file, "loop_counter")
+	out, err := runGun(t, "leaf", file, strconv.Itoa(line), "--", "-v")
+	if err != nil {
+		t.Fatalf("gun leaf loop failed: %v\n%s", err, out)
+	}
+	mustContain(t, out, "RUN:TestLoops/0")
+	mustContain(t, out, "RUN:TestLoops/2")
+	mustNotContain(t, out, "RUN:TestLoops/red")
+}
+
+func TestLeafRunsEveryCallSiteOfHelper(t *testing.T) {
+	file := testutil.FixturePath(t, "sample", "helpers_test.go")
+	line := testutil.MarkerLine(t, file, "helper_nested")
+	out, err := runGun(t, "leaf", file, strconv.Itoa(line), "--", "-v")
+	if err != nil {
+		t.Fatalf("gun leaf helper failed: %v\n%s", err, out)
+	}
+	mustContain(t, out, "RUN:TestHelpers/create/nested")
+	mustContain(t, out, "RUN:TestHelpers/create_again/nested")
+	mustContain(t, out, "RUN:TestHelpersElsewhere/reuse/nested")
+	mustNotContain(t, out, "RUN:TestHelpers/double")
+}
+
+func TestLeafRunsEveryLambdaCallSite(t *testing.T) {
+	file := testutil.FixturePath(t, "sample", "closures_test.go")
+	line := testutil.MarkerLine(t, file, "closure_lambda")
+	out, err := runGun(t, "leaf", file, strconv.Itoa(line), "--", "-v")
+	if err != nil {
+		t.Fatalf("gun leaf lambda failed: %v\n%s", err, out)
+	}
+	mustContain(t, out, "RUN:TestClosures/two")
+	mustContain(t, out, "RUN:TestClosures/four")
+	mustContain(t, out, "RUN:TestClosures/six")
+	mustNotContain(t, out, "RUN:TestClosures/iife")
+
+	line = testutil.MarkerLine(t, file, "closure_captured")
+	out, err = runGun(t, "leaf", file, strconv.Itoa(line), "--", "-v")
+	if err != nil {
+		t.Fatalf("gun leaf captured lambda failed: %v\n%s", err, out)
+	}
+	mustContain(t, out, "RUN:TestClosures/inner")
+}
+
+func TestLeafRunsSubtestOfCapturedReceiver(t *testing.T) {
+	file := testutil.FixturePath(t, "sample", "typed_test.go")
+	line := testutil.MarkerLine(t, file, "typed_outer")
+	out, err := runGun(t, "leaf", file, strconv.Itoa(line), "--", "-v")
+	if err != nil {
+		t.Fatalf("gun leaf captured receiver failed: %v\n%s", err, out)
+	}
+	mustContain(t, out, "RUN:TestOuter/b")
+	mustNotContain(t, out, "RUN:TestOuter/a/b")
+}
+
+func TestLeafRunsSubtestStartedByHelperPackage(t *testing.T) {
+	file := testutil.FixturePath(t, "sample", "wrappers_test.go")
+	line := testutil.MarkerLine(t, file, "wrap_prefixed")
+	out, err := runGun(t, "leaf", file, strconv.Itoa(line), "--", "-v")
+	if err != nil {
+		t.Fatalf("gun leaf wrapper failed: %v\n%s", err, out)
+	}
+	mustContain(t, out, "RUN:TestWrappers/case-slow")
+	mustNotContain(t, out, "RUN:TestWrappers/remote")
+}
+
+func TestLeafHonoursPassthroughTags(t *testing.T) {
+	file := testutil.FixturePath(t, "platform", "platform_test.go")
+	line := testutil.MarkerLine(t, file, "platform_suite")
+	out, err := runGun(t, "leaf", file, strconv.Itoa(line), "--", "-tags", "integration", "-v")
+	if err != nil {
+		t.Fatalf("gun leaf with tags failed: %v\n%s", err, out)
+	}
+	mustContain(t, out, "RUN:TestPlatform/integration")
+	mustNotContain(t, out, "RUN:TestPlatform/unit")
+}
+
+func TestTestInfersTagsFromBuildConstraint(t *testing.T) {
+	file := testutil.FixturePath(t, "platform", "suite_integration_test.go")
+	line := testutil.MarkerLine(t, file, "platform_integration")
+	out, err := runGun(t, "test", file, strconv.Itoa(line), "--", "-v")
+	if err != nil {
+		t.Fatalf("gun test with inferred tags failed: %v\n%s", err, out)
+	}
+	mustContain(t, out, "inferred -tags integration")
+	mustContain(t, out, "RUN:TestIntegrationOnly")
+}
+
+func TestLeafResolvesExternalTestPackage(t *testing.T) {
+	file := testutil.FixturePath(t, "extpkg", "ext_test.go")
+	line := testutil.MarkerLine(t, file, "ext_index")
+	out, err := runGun(t, "leaf", file, strconv.Itoa(line), "--", "-v")
+	if err != nil {
+		t.Fatalf("gun leaf external test failed: %v\n%s", err, out)
+	}
+	mustContain(t, out, "RUN:TestExternal/slow")
+	mustNotContain(t, out, "RUN:TestExternal/hello")
+}
+
+func TestAutoModeRunsTestsReferencingSourceLine(t *testing.T) {
+	file := testutil.FixturePath(t, "calc", "calc.go")
+	line := testutil.MarkerLine(t, file, "calc_sub")
+	out, err := runGun(t, file+":"+strconv.Itoa(line), "--", "-v")
+	if err != nil {
+		t.Fatalf("gun auto source line failed: %v\n%s", err, out)
+	}
+	mustContain(t, out, "Sub is referenced by TestAdd, TestSubExternal")
+	mustContain(t, out, "RUN:TestAdd/large")
+	mustContain(t, out, "RUN:TestSubExternal/external")
+	mustNotContain(t, out, "RUN:TestAdd/small")
+	mustNotContain(t, out, "RUN:TestSubExternal/other")
+}
+
+func TestRdepsRunsImportingPackages(t *testing.T) {
+	file := testutil.FixturePath(t, "rdeps", "base", "base.go")
+	line := testutil.MarkerLine(t, file, "rdeps_base")
+	out, err := runGun(t, "rdeps", "--list", file, strconv.Itoa(line))
+	if err != nil {
+		t.Fatalf("gun rdeps --list failed: %v\n%s", err, out)
+	}
+	mustContain(t, out, "example.com/fixturemod/rdeps/top")
+
+	out, err = runGun(t, "rdeps", "--depth", "1", file, strconv.Itoa(line), "--", "-v")
+	if err != nil {
+		t.Fatalf("gun rdeps failed: %v\n%s", err, out)
+	}
+	mustContain(t, out, "RUN:TestGreeting")
+	mustContain(t, out, "RUN:TestShout")
+	mustNotContain(t, out, "RUN:TestBanner")
+}
+
+func TestAutoModeRunsTableCase(t *testing.T) {
+	file := testutil.FixturePath(t, "sample", "table_test.go")
+	line := testutil.MarkerLine(t, file, "table_keyed")
+	out, err := runGun(t, file, strconv.Itoa(line), "--", "-v")
+	if err != nil {
+		t.Fatalf("gun auto table case failed: %v\n%s", err, out)
+	}
+	mustContain(t, out, "RUN:Table/second")
+	mustNotContain(t, out, "RUN:Table/first")
+}
+
+func TestLeafRunsRewrittenSubtestName(t *testing.T) {
+	file := testutil.FixturePath(t, "sample", "names_test.go")
+	line := testutil.MarkerLine(t, file, "names_dup_second")
+	out, err := runGun(t, "leaf", file, strconv.Itoa(line), "--", "-v")
+	if err != nil {
+		t.Fatalf("gun leaf rewritten name failed: %v\n%s", err, out)
+	}
+	mustContain(t, out, "RUN:TestNames/dup#01")
+	mustNotContain(t, out, "RUN:TestNames/dup#02")
+
+	line = testutil.MarkerLine(t, file, "names_space")
+	out, err = runGun(t, "leaf", file, strconv.Itoa(line), "--", "-v")
+	if err != nil {
+		t.Fatalf("gun leaf spaced name failed: %v\n%s", err, out)
+	}
+	mustContain(t, out, "RUN:TestNames/adds_two_numbers")
+}
+
+func TestLeafRunsSubtestNameWithSlash(t *testing.T) {
+	file := testutil.FixturePath(t, "sample", "names_test.go")
+	line := testutil.MarkerLine(t, file, "names_slash_nested")
+	out, err := runGun(t, "leaf", file, strconv.Itoa(line), "--", "-v")
+	if err != nil {
+		t.Fatalf("gun leaf slashed name failed: %v\n%s", err, out)
+	}
+	mustContain(t, out, "RUN:TestNames/in/out/deep/er")
+	mustNotContain(t, out, "RUN:TestNames/dup")
+}
+
+func TestLeafRunsNestedBenchmark(t *testing.T) {
+	file := testutil.FixturePath(t, "sample", "bench_test.go")
+	line := testutil.MarkerLine(t, file, "bench_nested")
+	out, err := runGun(t, "leaf", file, strconv.Itoa(line), "--", "-benchtime=1x")
+	if err != nil {
+		t.Fatalf("gun leaf benchmark failed: %v\n%s", err, out)
+	}
+	mustContain(t, out, "RUN:BenchAnswer/large/nested")
+	mustNotContain(t, out, "RUN:BenchAnswer/small")
+	mustNotContain(t, out, "RUN:Alpha")
+}
+
+func TestAutoModeRunsSingleFuzzSeed(t *testing.T) {
+	file := testutil.FixturePath(t, "sample", "fuzz_test.go")
+	line := testutil.MarkerLine(t, file, "fuzz_seed")
+	out, err := runGun(t, file, strconv.Itoa(line), "--", "-v")
+	if err != nil {
+		t.Fatalf("gun auto fuzz seed failed: %v\n%s", err, out)
+	}
+	mustContain(t, out, "RUN:FuzzEcho/beta")
+	mustNotContain(t, out, "RUN:FuzzEcho/alpha")
+}
+
+func TestAutoModeReplaysCorpusEntry(t *testing.T) {
+	file := testutil.FixturePath(t, "sample", "testdata", "fuzz", "FuzzEcho", "4f1c2a7e")
+	out, err := runGun(t, file, "--", "-v")
+	if err != nil {
+		t.Fatalf("gun corpus entry failed: %v\n%s", err, out)
+	}
+	mustContain(t, out, "RUN:FuzzEcho/gamma")
+	mustNotContain(t, out, "RUN:FuzzEcho/alpha")
+
+	fuzzFile := testutil.FixturePath(t, "sample", "fuzz_test.go")
+	out2, err := runGun(t, "fuzz-corpus", fuzzFile, "1")
+	if err != nil {
+		t.Fatalf("gun fuzz-corpus failed: %v\n%s", err, out2)
+	}
+	mustContain(t, out2, "FuzzEcho:\n")
+	mustContain(t, out2, filepath.Join("FuzzEcho", "4f1c2a7e"))
+	mustContain(t, out2, "FuzzLooped:\n  (no corpus entries)")
+}
+
+func TestExampleWithoutOutputIsFlagged(t *testing.T) {
+	file := testutil.FixturePath(t, "sample", "example_test.go")
+	out, err := runGun(t, file, strconv.Itoa(testutil.MarkerLine(t, file, "example_func")), "--", "-v")
+	if err != nil {
+		t.Fatalf("gun example failed: %v\n%s", err, out)
+	}
+	mustContain(t, out, "--- PASS: ExampleAnswer ")
+
+	out2, err := runGun(t, file, strconv.Itoa(testutil.MarkerLine(t, file, "example_no_output")))
+	if err == nil {
+		t.Fatalf("expected example without output to be flagged")
+	}
+	if code := exitCode(err); code != 2 {
+		t.Fatalf("exit code = %d, want 2\n%s", code, out2)
+	}
+	mustContain(t, out2, "never runs it")
+}
+
 func TestParentAndOverflowUp(t *testing.T) {
 	file := testutil.FixtureFile(t)
 	line := testutil.MarkerLine(t, file, "inner")
@@ -158,6 +401,13 @@ func mustContain(t *testing.T, out, want string) {
 	}
 }
 
+func mustNotContain(t *testing.T, out, unwanted string) {
+	t.Helper()
+	if strings.Contains(out, unwanted) {
+		t.Fatalf("output unexpectedly contains %q\n%s", unwanted, out)
+	}
+}
+
 func exitCode(err error) int {
 	var ee *exec.ExitError
 	if errors.As(err, &ee) {
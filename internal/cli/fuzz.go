@@ -0,0 +1,25 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/loheagn/gun/internal/locator"
+)
+
+func newFuzzCommand() *cobra.Command {
+	var fuzztime string
+	cmd := &cobra.Command{
+		Use:   "fuzz <file> <line> | <file>:<line>",
+		Short: "Fuzz the containing FuzzXxx target",
+		Args:  cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var extra []string
+			if fuzztime != "" {
+				extra = append(extra, "-fuzztime", fuzztime)
+			}
+			return runModeWithArgs(cmd, args, locator.ModeFuzz, locator.ResolveOptions{}, extra)
+		},
+	}
+	cmd.Flags().StringVar(&fuzztime, "fuzztime", "", "fuzzing duration or iteration count passed to go test -fuzztime")
+	return cmd
+}
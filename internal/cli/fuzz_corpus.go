@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/loheagn/gun/internal/input"
+	"github.com/loheagn/gun/internal/locator"
+)
+
+func newFuzzCorpusCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "fuzz-corpus <file> <line> | <file>:<line> | <corpus-file>",
+		Short: "List testdata/fuzz corpus entries of the containing FuzzXxx (or every FuzzXxx in the file)",
+		Args:  cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			positional, passthrough := splitArgs(cmd, args)
+			target, err := input.ParseFileLine(positional)
+			if err != nil {
+				return err
+			}
+			pkgDir := filepath.Dir(target.File)
+			targets := []string{target.FuzzTarget}
+			if target.CorpusEntry != "" {
+				pkgDir = locator.CorpusPackageDir(target.File)
+			} else {
+				targets, err = locator.FuzzTargetsAt(target.File, target.Line, buildOptions(cmd, locator.ResolveOptions{}, passthrough))
+				if err != nil {
+					return err
+				}
+			}
+			entries, err := locator.ListCorpus(pkgDir, targets)
+			if err != nil {
+				return err
+			}
+			printCorpus(cmd, targets, entries)
+			return nil
+		},
+	}
+}
+
+func printCorpus(cmd *cobra.Command, targets []string, entries []locator.CorpusEntry) {
+	out := cmd.OutOrStdout()
+	wd, _ := os.Getwd()
+	for _, target := range targets {
+		var paths []string
+		for _, entry := range entries {
+			if entry.Target != target {
+				continue
+			}
+			path := entry.Path
+			if rel, err := filepath.Rel(wd, path); err == nil && wd != "" {
+				path = rel
+			}
+			paths = append(paths, path)
+		}
+		fmt.Fprintf(out, "%s:\n", target)
+		if len(paths) == 0 {
+			fmt.Fprintln(out, "  (no corpus entries)")
+		}
+		for _, path := range paths {
+			fmt.Fprintf(out, "  %s\n", path)
+		}
+	}
+}
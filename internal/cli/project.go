@@ -7,7 +7,6 @@ import (
 
 	"github.com/loheagn/gun/internal/input"
 	"github.com/loheagn/gun/internal/locator"
-	"github.com/loheagn/gun/internal/runner"
 )
 
 func newProjectCommand() *cobra.Command {
@@ -22,15 +21,11 @@ func newProjectCommand() *cobra.Command {
 				return err
 			}
 			passthrough = append(inferredPassthrough, passthrough...)
-			res, err := locator.Resolve(locator.ModeProject, target.File, target.Line, locator.ResolveOptions{ProjectRoot: root})
+			res, err := resolveTarget(locator.ModeProject, target, buildOptions(cmd, locator.ResolveOptions{ProjectRoot: root}, passthrough))
 			if err != nil {
 				return err
 			}
-			inv, err := runner.BuildInvocation(res, passthrough)
-			if err != nil {
-				return err
-			}
-			return runner.Run(inv)
+			return execute(cmd, res, passthrough)
 		},
 	}
 }
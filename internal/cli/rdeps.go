@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/loheagn/gun/internal/errs"
+	"github.com/loheagn/gun/internal/locator"
+)
+
+func newRdepsCommand() *cobra.Command {
+	var depth int
+	var list bool
+	cmd := &cobra.Command{
+		Use:   "rdeps <file> <line> [project-root] | <file>:<line> [project-root]",
+		Short: "Run tests in every package that transitively imports the file's package",
+		Args:  cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if depth < 0 {
+				return errs.New(errs.CodeUsage, "--depth must be >= 0", nil)
+			}
+			positional, passthrough := splitArgs(cmd, args)
+			target, root, inferredPassthrough, err := parseProjectTarget(positional, cmd.ArgsLenAtDash() >= 0)
+			if err != nil {
+				return err
+			}
+			passthrough = append(inferredPassthrough, passthrough...)
+			res, err := resolveTarget(locator.ModeRdeps, target, buildOptions(cmd, locator.ResolveOptions{ProjectRoot: root, Depth: depth}, passthrough))
+			if err != nil {
+				return err
+			}
+			if list {
+				for _, pkg := range res.Packages {
+					fmt.Fprintln(cmd.OutOrStdout(), pkg)
+				}
+				return nil
+			}
+			return execute(cmd, res, passthrough)
+		},
+	}
+	cmd.Flags().IntVar(&depth, "depth", 0, "maximum import distance from the file's package (0 means unlimited)")
+	cmd.Flags().BoolVar(&list, "list", false, "print the packages instead of running their tests")
+	return cmd
+}
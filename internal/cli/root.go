@@ -1,6 +1,8 @@
 package cli
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 
 	"github.com/loheagn/gun/internal/errs"
@@ -21,17 +23,15 @@ func NewRootCommand() *cobra.Command {
 			if err != nil {
 				return err
 			}
-			res, err := locator.Resolve(locator.ModeAuto, target.File, target.Line, locator.ResolveOptions{})
-			if err != nil {
-				return err
-			}
-			inv, err := runner.BuildInvocation(res, passthrough)
+			res, err := resolveTarget(locator.ModeAuto, target, buildOptions(cmd, locator.ResolveOptions{}, passthrough))
 			if err != nil {
 				return err
 			}
-			return runner.Run(inv)
+			return execute(cmd, res, passthrough)
 		},
 	}
+	cmd.PersistentFlags().String("goos", "", "target GOOS used to select files and passed to go test")
+	cmd.PersistentFlags().String("goarch", "", "target GOARCH used to select files and passed to go test")
 
 	cmd.AddCommand(
 		newLeafCommand(),
@@ -40,6 +40,9 @@ func NewRootCommand() *cobra.Command {
 		newFileCommand(),
 		newPkgCommand(),
 		newProjectCommand(),
+		newRdepsCommand(),
+		newFuzzCommand(),
+		newFuzzCorpusCommand(),
 	)
 	return cmd
 }
@@ -96,18 +99,44 @@ func targetAndPassthrough(cmd *cobra.Command, args []string) (input.Target, []st
 	return target, append(rest, passthrough...), nil
 }
 
+func resolveTarget(mode locator.Mode, target input.Target, opts locator.ResolveOptions) (locator.Resolution, error) {
+	if target.CorpusEntry != "" {
+		return locator.ResolveCorpus(mode, target.File, target.FuzzTarget, target.CorpusEntry, opts)
+	}
+	return locator.Resolve(mode, target.File, target.Line, opts)
+}
+
 func runMode(cmd *cobra.Command, args []string, mode locator.Mode, opts locator.ResolveOptions) error {
+	return runModeWithArgs(cmd, args, mode, opts, nil)
+}
+
+func runModeWithArgs(cmd *cobra.Command, args []string, mode locator.Mode, opts locator.ResolveOptions, extra []string) error {
 	target, passthrough, err := targetAndPassthrough(cmd, args)
 	if err != nil {
 		return err
 	}
-	res, err := locator.Resolve(mode, target.File, target.Line, opts)
+	passthrough = append(extra, passthrough...)
+	res, err := resolveTarget(mode, target, buildOptions(cmd, opts, passthrough))
 	if err != nil {
 		return err
 	}
+	return execute(cmd, res, passthrough)
+}
+
+func buildOptions(cmd *cobra.Command, opts locator.ResolveOptions, passthrough []string) locator.ResolveOptions {
+	opts.BuildTags = runner.BuildTags(passthrough)
+	opts.GOOS, _ = cmd.Flags().GetString("goos")
+	opts.GOARCH, _ = cmd.Flags().GetString("goarch")
+	return opts
+}
+
+func execute(cmd *cobra.Command, res locator.Resolution, passthrough []string) error {
 	inv, err := runner.BuildInvocation(res, passthrough)
 	if err != nil {
 		return err
 	}
+	for _, note := range res.Notes {
+		fmt.Fprintln(cmd.ErrOrStderr(), "gun:", note)
+	}
 	return runner.Run(inv)
 }
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/loheagn/gun/internal/errs"
+)
+
+const FileName = ".gun.yaml"
+
+type Config struct {
+	Path    string   `yaml:"-"`
+	Runners []Runner `yaml:"runners"`
+}
+
+type Runner struct {
+	Package  string `yaml:"package"`
+	Func     string `yaml:"func"`
+	Name     int    `yaml:"name"`
+	Callback int    `yaml:"callback"`
+}
+
+func Find(startDir string) (string, error) {
+	dir, err := filepath.Abs(startDir)
+	if err != nil {
+		return "", errs.New(errs.CodeUsage, "failed to resolve directory", err)
+	}
+	dir = filepath.Clean(dir)
+	for {
+		path := filepath.Join(dir, FileName)
+		if st, err := os.Stat(path); err == nil && !st.IsDir() {
+			return path, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+func Load(startDir string) (*Config, error) {
+	path, err := Find(startDir)
+	if err != nil || path == "" {
+		return &Config{}, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errs.New(errs.CodeUsage, fmt.Sprintf("failed to read %s", path), err)
+	}
+	cfg := &Config{Path: path}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, errs.New(errs.CodeUsage, fmt.Sprintf("invalid %s", path), err)
+	}
+	for i, r := range cfg.Runners {
+		if r.Package == "" || r.Func == "" {
+			return nil, errs.New(errs.CodeUsage, fmt.Sprintf("invalid %s: runners[%d] needs package and func", path, i), nil)
+		}
+		if r.Name < 0 || r.Callback < 0 || r.Name == r.Callback {
+			return nil, errs.New(errs.CodeUsage, fmt.Sprintf("invalid %s: runners[%d] needs distinct non-negative name and callback argument indexes", path, i), nil)
+		}
+	}
+	return cfg, nil
+}
+
+func (c *Config) Runner(pkgPath string, name string) (Runner, bool) {
+	for _, r := range c.Runners {
+		if r.Package == pkgPath && r.Func == name {
+			return r, true
+		}
+	}
+	return Runner{}, false
+}
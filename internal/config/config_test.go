@@ -0,0 +1,53 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadFindsConfigInParent(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "pkg", "sub")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	content := "runners:\n  - package: example.com/m/testkit\n    func: Case\n    name: 1\n    callback: 2\n"
+	if err := os.WriteFile(filepath.Join(root, FileName), []byte(content), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	cfg, err := Load(nested)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Path != filepath.Join(root, FileName) {
+		t.Fatalf("path = %q", cfg.Path)
+	}
+	r, ok := cfg.Runner("example.com/m/testkit", "Case")
+	if !ok || r.Name != 1 || r.Callback != 2 {
+		t.Fatalf("runner = %+v, ok = %v", r, ok)
+	}
+}
+
+func TestLoadWithoutConfig(t *testing.T) {
+	cfg, err := Load(t.TempDir())
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(cfg.Runners) != 0 {
+		t.Fatalf("runners = %+v", cfg.Runners)
+	}
+}
+
+func TestLoadRejectsInvalidRunner(t *testing.T) {
+	dir := t.TempDir()
+	content := "runners:\n  - package: example.com/m/testkit\n    func: Case\n    name: 1\n    callback: 1\n"
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(content), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if _, err := Load(dir); err == nil || !strings.Contains(err.Error(), "runners[0]") {
+		t.Fatalf("expected runner error, got %v", err)
+	}
+}
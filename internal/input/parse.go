@@ -11,8 +11,10 @@ import (
 )
 
 type Target struct {
-	File string
-	Line int
+	File        string
+	Line        int
+	FuzzTarget  string
+	CorpusEntry string
 }
 
 func ParseFileLine(args []string) (Target, error) {
@@ -20,6 +22,9 @@ func ParseFileLine(args []string) (Target, error) {
 	case 1:
 		file, line, ok := splitFileLine(args[0])
 		if !ok {
+			if target, ok := corpusTarget(args[0]); ok {
+				return target, nil
+			}
 			return Target{}, errs.New(errs.CodeUsage, "expected <file> <line> or <file>:<line>", nil)
 		}
 		return normalizeTarget(file, line)
@@ -77,8 +82,12 @@ func normalizeTarget(file string, line int) (Target, error) {
 	if line <= 0 {
 		return Target{}, errs.New(errs.CodeUsage, "line number must be > 0", nil)
 	}
-	if !strings.HasSuffix(file, "_test.go") {
-		return Target{}, errs.New(errs.CodeUsage, "input file must end with _test.go", nil)
+	if target, ok := corpusTarget(file); ok {
+		target.Line = line
+		return target, nil
+	}
+	if !strings.HasSuffix(file, ".go") {
+		return Target{}, errs.New(errs.CodeUsage, "input file must be a .go file", nil)
 	}
 	abs, err := filepath.Abs(file)
 	if err != nil {
@@ -87,10 +96,35 @@ func normalizeTarget(file string, line int) (Target, error) {
 	abs = filepath.Clean(abs)
 	st, err := os.Stat(abs)
 	if err != nil {
-		return Target{}, errs.New(errs.CodeUsage, fmt.Sprintf("test file %q not found", abs), err)
+		return Target{}, errs.New(errs.CodeUsage, fmt.Sprintf("file %q not found", abs), err)
 	}
 	if st.IsDir() {
 		return Target{}, errs.New(errs.CodeUsage, fmt.Sprintf("%q is a directory", abs), nil)
 	}
 	return Target{File: abs, Line: line}, nil
 }
+
+func corpusTarget(file string) (Target, bool) {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return Target{}, false
+	}
+	abs = filepath.Clean(abs)
+	targetDir := filepath.Dir(abs)
+	fuzzDir := filepath.Dir(targetDir)
+	if filepath.Base(fuzzDir) != "fuzz" || filepath.Base(filepath.Dir(fuzzDir)) != "testdata" {
+		return Target{}, false
+	}
+	if !strings.HasPrefix(filepath.Base(targetDir), "Fuzz") {
+		return Target{}, false
+	}
+	st, err := os.Stat(abs)
+	if err != nil || st.IsDir() {
+		return Target{}, false
+	}
+	return Target{
+		File:        abs,
+		FuzzTarget:  filepath.Base(targetDir),
+		CorpusEntry: filepath.Base(abs),
+	}, true
+}
@@ -38,13 +38,13 @@ func TestParseFileLine(t *testing.T) {
 
 func TestParseFileLineRejectsInvalid(t *testing.T) {
 	dir := t.TempDir()
-	nonTestFile := filepath.Join(dir, "a.go")
-	if err := os.WriteFile(nonTestFile, []byte("package x\n"), 0o644); err != nil {
+	nonGoFile := filepath.Join(dir, "a.txt")
+	if err := os.WriteFile(nonGoFile, []byte("x\n"), 0o644); err != nil {
 		t.Fatalf("write file: %v", err)
 	}
 
-	if _, err := ParseFileLine([]string{nonTestFile, "1"}); err == nil {
-		t.Fatalf("expected _test.go suffix error")
+	if _, err := ParseFileLine([]string{nonGoFile, "1"}); err == nil {
+		t.Fatalf("expected .go suffix error")
 	}
 
 	testFile := filepath.Join(dir, "b_test.go")
@@ -56,6 +56,22 @@ func TestParseFileLineRejectsInvalid(t *testing.T) {
 	}
 }
 
+func TestParseFileLineSourceFile(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "a.go")
+	if err := os.WriteFile(file, []byte("package x\n"), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	target, err := ParseFileLine([]string{file + ":4"})
+	if err != nil {
+		t.Fatalf("ParseFileLine source file: %v", err)
+	}
+	if target.File != file || target.Line != 4 {
+		t.Fatalf("unexpected source target: %+v", target)
+	}
+}
+
 func TestParseFileLineWithOptionalRoot(t *testing.T) {
 	dir := t.TempDir()
 	file := filepath.Join(dir, "a_test.go")
@@ -79,3 +95,27 @@ func TestParseFileLineWithOptionalRoot(t *testing.T) {
 		t.Fatalf("unexpected parse result: %+v root=%q", target2, root2)
 	}
 }
+
+func TestParseFileLineCorpusEntry(t *testing.T) {
+	dir := t.TempDir()
+	corpusDir := filepath.Join(dir, "testdata", "fuzz", "FuzzParse")
+	if err := os.MkdirAll(corpusDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	file := filepath.Join(corpusDir, "abc123")
+	if err := os.WriteFile(file, []byte("go test fuzz v1\nstring(\"x\")\n"), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	target, err := ParseFileLine([]string{file})
+	if err != nil {
+		t.Fatalf("ParseFileLine corpus: %v", err)
+	}
+	if target.File != file || target.FuzzTarget != "FuzzParse" || target.CorpusEntry != "abc123" {
+		t.Fatalf("unexpected corpus target: %+v", target)
+	}
+
+	if _, err := ParseFileLine([]string{filepath.Join(corpusDir, "missing")}); err == nil {
+		t.Fatalf("expected missing corpus file error")
+	}
+}
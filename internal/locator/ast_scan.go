@@ -1,95 +1,393 @@
 package locator
 
 import (
+	"fmt"
 	"go/ast"
+	"go/doc"
 	"go/token"
+	"go/types"
 	"strings"
 	"unicode"
 	"unicode/utf8"
+
+	"github.com/loheagn/gun/internal/config"
 )
 
 type scanResult struct {
 	Tests []*Scope
 }
 
-func scanTests(file *ast.File, fset *token.FileSet, eval *evalContext) *scanResult {
-	testingAliases, testingDot := collectImportAliases(file, "testing")
+const quicktestPath = "github.com/frankban/quicktest"
+
+type paramType struct {
+	importPath string
+	name       string
+}
+
+var (
+	testingT      = paramType{importPath: "testing", name: "T"}
+	testingB      = paramType{importPath: "testing", name: "B"}
+	testingF      = paramType{importPath: "testing", name: "F"}
+	quicktestC    = paramType{importPath: quicktestPath, name: "C"}
+	suiteReceiver = paramType{importPath: testifySuitePath, name: "Suite"}
+)
+
+var receiverTypes = []paramType{testingT, testingB, quicktestC, suiteReceiver}
+
+var shadowedT = paramType{}
+
+type subtestFramework struct {
+	recv     paramType
+	wrappers []string
+}
+
+var subtestFrameworks = []subtestFramework{
+	{recv: testingT},
+	{recv: testingB},
+	{recv: quicktestC, wrappers: []string{"New"}},
+}
+
+type tEnv map[string]paramType
+
+type scanner struct {
+	file        *ast.File
+	filename    string
+	fset        *token.FileSet
+	eval        *evalContext
+	imports     map[string]importAliases
+	expanding   map[*ast.BlockStmt]bool
+	callbacks   map[types.Object]subtestCallback
+	calleeDepth int
+	funcs       []funcSpan
+	config      *config.Config
+}
+
+type subtestCall struct {
+	recv     ast.Expr
+	typ      paramType
+	name     ast.Expr
+	callback ast.Expr
+}
+
+type subtestCallback struct {
+	typ    *ast.FuncType
+	body   *ast.BlockStmt
+	callee string
+	eval   *evalContext
+}
+
+type importAliases struct {
+	names map[string]bool
+	dot   bool
+}
+
+type topLevelKind struct {
+	prefix string
+	kind   ScopeKind
+	tType  paramType
+}
+
+var topLevelKinds = []topLevelKind{
+	{prefix: "Test", kind: ScopeKindTest, tType: testingT},
+	{prefix: "Benchmark", kind: ScopeKindBenchmark, tType: testingB},
+	{prefix: "Fuzz", kind: ScopeKindFuzz, tType: testingF},
+	{prefix: "Example", kind: ScopeKindExample},
+}
+
+func scanTests(file *ast.File, fset *token.FileSet, eval *evalContext, suites *packageSuites, cfg *config.Config) *scanResult {
+	s := &scanner{
+		config:    cfg,
+		file:      file,
+		filename:  fset.Position(file.Pos()).Filename,
+		fset:      fset,
+		eval:      eval,
+		imports:   make(map[string]importAliases),
+		expanding: make(map[*ast.BlockStmt]bool),
+		callbacks: make(map[types.Object]subtestCallback),
+	}
+	if suites == nil {
+		suites = &packageSuites{}
+	}
 	res := &scanResult{}
+	withOutput := examplesWithOutput(file)
 	for _, decl := range file.Decls {
 		fn, ok := decl.(*ast.FuncDecl)
-		if !ok || fn.Recv != nil || fn.Body == nil {
+		if !ok || fn.Body == nil {
 			continue
 		}
-		if !isTopLevelTestName(fn.Name.Name) {
+		if fn.Recv != nil {
+			if method := s.scanSuiteMethod(fn, suites.testify); method != nil {
+				res.Tests = append(res.Tests, method)
+			} else if method := s.scanCheckMethod(fn, suites.gocheck); method != nil {
+				res.Tests = append(res.Tests, method)
+			}
 			continue
 		}
-		tVar, ok := extractTestingParamName(fn.Type, testingAliases, testingDot)
+		top, ok := matchTopLevelName(fn.Name.Name)
+		if !ok {
+			continue
+		}
+		if top.kind == ScopeKindExample {
+			if fn.Type.Params.NumFields() != 0 || fn.Type.Results.NumFields() != 0 {
+				continue
+			}
+			res.Tests = append(res.Tests, &Scope{
+				Name:           fn.Name.Name,
+				Kind:           ScopeKindExample,
+				StartLine:      fset.Position(fn.Body.Pos()).Line,
+				EndLine:        fset.Position(fn.Body.End()).Line,
+				NameResolvable: true,
+				NoOutput:       !withOutput[fn.Name.Name],
+			})
+			continue
+		}
+		tVar, ok := s.extractParamName(fn.Type, top.tType)
 		if !ok {
 			continue
 		}
 		testScope := &Scope{
 			Name:           fn.Name.Name,
-			Kind:           ScopeKindTest,
+			Kind:           top.kind,
 			StartLine:      fset.Position(fn.Body.Pos()).Line,
 			EndLine:        fset.Position(fn.Body.End()).Line,
 			NameResolvable: true,
 		}
-		if tVar != "" {
-			scanStmtList(fn.Body.List, tVar, testScope, fset, testingAliases, testingDot, eval)
+		switch {
+		case tVar == "":
+		case top.kind == ScopeKindFuzz:
+			s.scanFuzzSeeds(fn.Body.List, tVar, testScope)
+		default:
+			s.scanStmtList(fn.Body.List, tEnv{tVar: top.tType}, testScope)
 		}
 		res.Tests = append(res.Tests, testScope)
 	}
+	for _, test := range res.Tests {
+		test.File = s.filename
+	}
 	return res
 }
 
-func scanStmtList(stmts []ast.Stmt, currentT string, parent *Scope, fset *token.FileSet, testingAliases map[string]bool, testingDot bool, eval *evalContext) {
+func (s *scanner) aliases(importPath string) importAliases {
+	if a, ok := s.imports[importPath]; ok {
+		return a
+	}
+	names, dot := collectImportAliases(s.file, importPath)
+	a := importAliases{names: names, dot: dot}
+	s.imports[importPath] = a
+	return a
+}
+
+func (s *scanner) scanSuiteMethod(fn *ast.FuncDecl, suites map[string][]string) *Scope {
+	typeName, recvVar := receiverTypeName(fn.Recv)
+	entries := suites[typeName]
+	if len(entries) == 0 || !hasTopLevelPrefix(fn.Name.Name, "Test") {
+		return nil
+	}
+	if fn.Type.Params.NumFields() != 0 || fn.Type.Results.NumFields() != 0 {
+		return nil
+	}
+	method := &Scope{
+		Name:           fn.Name.Name,
+		Kind:           ScopeKindSuiteMethod,
+		StartLine:      s.fset.Position(fn.Body.Pos()).Line,
+		EndLine:        s.fset.Position(fn.Body.End()).Line,
+		NameResolvable: true,
+		Entries:        entries,
+	}
+	if recvVar != "" && recvVar != "_" {
+		s.scanStmtList(fn.Body.List, tEnv{recvVar: suiteReceiver}, method)
+	}
+	return method
+}
+
+func (s *scanner) scanStmtList(stmts []ast.Stmt, env tEnv, parent *Scope) {
 	for _, stmt := range stmts {
-		scanStmt(stmt, currentT, parent, fset, testingAliases, testingDot, eval)
+		s.scanStmt(stmt, env, parent)
 	}
 }
 
-func scanStmt(stmt ast.Stmt, currentT string, parent *Scope, fset *token.FileSet, testingAliases map[string]bool, testingDot bool, eval *evalContext) {
+func (s *scanner) scanStmt(stmt ast.Stmt, env tEnv, parent *Scope) {
 	ast.Inspect(stmt, func(n ast.Node) bool {
 		switch node := n.(type) {
 		case *ast.FuncLit:
+			if !s.isLambda(node) {
+				s.scanStmtList(node.Body.List, closureEnv(node.Type, env), parent)
+			}
 			return false
+		case *ast.AssignStmt:
+			s.bindWrappers(node, env)
 		case *ast.CallExpr:
-			child, nextT, callbackBody := matchSubtestCall(node, currentT, fset, testingAliases, testingDot, eval)
-			if child == nil {
+			sub, ok := s.subtestCallOf(node, env)
+			if !ok {
+				s.scanLambdaCall(node, env, parent)
+				s.scanHelperCall(node, env, parent)
 				return true
 			}
-			child.Parent = parent
-			parent.Children = append(parent.Children, child)
-			if callbackBody != nil && nextT != "" {
-				scanStmtList(callbackBody.List, nextT, child, fset, testingAliases, testingDot, eval)
+			owner := s.ownerScope(sub.recv, parent)
+			child, nextEnv, callback := s.matchSubtestCall(node, sub)
+			child.Parent = owner
+			if owner != parent {
+				child.CallSite = parent
 			}
+			owner.Children = append(owner.Children, child)
+			if callback.body != nil && len(nextEnv) > 0 && !s.expanding[callback.body] {
+				s.scanCallback(callback, nextEnv, child)
+			}
+			if !child.NameResolvable {
+				for _, tc := range tableCaseScopes(sub.name, s.fset, s.eval) {
+					tc.Parent = owner
+					owner.Children = append(owner.Children, tc)
+				}
+			}
+			return false
 		}
 		return true
 	})
 }
 
-func matchSubtestCall(call *ast.CallExpr, currentT string, fset *token.FileSet, testingAliases map[string]bool, testingDot bool, eval *evalContext) (*Scope, string, *ast.BlockStmt) {
-	sel, ok := call.Fun.(*ast.SelectorExpr)
-	if !ok || sel.Sel.Name != "Run" {
-		return nil, "", nil
+func (s *scanner) scanCallback(callback subtestCallback, env tEnv, parent *Scope) {
+	outer := s.eval
+	if callback.eval != nil {
+		s.eval = callback.eval
 	}
-	recv, ok := sel.X.(*ast.Ident)
-	if !ok || recv.Name != currentT {
-		return nil, "", nil
+	s.expanding[callback.body] = true
+	s.enterFunc(callback.typ, callback.body)
+	s.scanStmtList(callback.body.List, env, parent)
+	s.leaveFunc()
+	delete(s.expanding, callback.body)
+	s.eval = outer
+}
+
+func (s *scanner) bindWrappers(assign *ast.AssignStmt, env tEnv) {
+	if len(assign.Lhs) != len(assign.Rhs) {
+		return
+	}
+	for i, rhs := range assign.Rhs {
+		ident, ok := assign.Lhs[i].(*ast.Ident)
+		if !ok || ident.Name == "_" {
+			continue
+		}
+		if _, isIdent := rhs.(*ast.Ident); isIdent {
+			continue
+		}
+		if typ, ok := s.receiverType(rhs, env); ok {
+			env[ident.Name] = typ
+		}
+	}
+}
+
+func (s *scanner) receiverType(expr ast.Expr, env tEnv) (paramType, bool) {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		typ, ok := env[e.Name]
+		return typ, ok && typ != shadowedT
+	case *ast.ParenExpr:
+		return s.receiverType(e.X, env)
+	case *ast.CallExpr:
+		if len(e.Args) != 1 {
+			return paramType{}, false
+		}
+		if _, ok := s.receiverType(e.Args[0], env); !ok {
+			return paramType{}, false
+		}
+		for _, fw := range subtestFrameworks {
+			a := s.aliases(fw.recv.importPath)
+			for _, wrapper := range fw.wrappers {
+				if isPackageFunc(e.Fun, wrapper, a.names, a.dot) {
+					return fw.recv, true
+				}
+			}
+		}
+	}
+	return paramType{}, false
+}
+
+func (s *scanner) subtestCallOf(call *ast.CallExpr, env tEnv) (subtestCall, bool) {
+	if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Run" {
+		typ, ok := s.typedReceiver(sel, env)
+		if !ok {
+			typ, ok = s.receiverType(sel.X, env)
+		}
+		if ok {
+			if len(call.Args) < 2 {
+				return subtestCall{}, false
+			}
+			return subtestCall{recv: sel.X, typ: typ, name: call.Args[0], callback: call.Args[1]}, true
+		}
+	}
+	runner, ok := s.configRunner(call.Fun)
+	if !ok || runner.Name >= len(call.Args) || runner.Callback >= len(call.Args) {
+		return subtestCall{}, false
+	}
+	return subtestCall{name: call.Args[runner.Name], callback: call.Args[runner.Callback]}, true
+}
+
+func (s *scanner) configRunner(fun ast.Expr) (config.Runner, bool) {
+	if s.config == nil || s.eval == nil || s.eval.info == nil {
+		return config.Runner{}, false
+	}
+	switch f := ast.Unparen(fun).(type) {
+	case *ast.IndexExpr:
+		fun = f.X
+	case *ast.IndexListExpr:
+		fun = f.X
+	}
+	var ident *ast.Ident
+	switch f := ast.Unparen(fun).(type) {
+	case *ast.Ident:
+		ident = f
+	case *ast.SelectorExpr:
+		ident = f.Sel
+	default:
+		return config.Runner{}, false
+	}
+	fn, ok := s.eval.info.Uses[ident].(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return config.Runner{}, false
 	}
-	if len(call.Args) < 2 {
-		return nil, "", nil
+	fn = fn.Origin()
+	name := fn.Name()
+	if recv := fn.Signature().Recv(); recv != nil {
+		typ := recv.Type()
+		if ptr, ok := typ.(*types.Pointer); ok {
+			typ = ptr.Elem()
+		}
+		named, ok := typ.(*types.Named)
+		if !ok {
+			return config.Runner{}, false
+		}
+		name = named.Obj().Name() + "." + name
 	}
+	return s.config.Runner(fn.Pkg().Path(), name)
+}
 
-	name, resolvable := evalString(call.Args[0], eval)
-	callback, _ := call.Args[1].(*ast.FuncLit)
-	startLine := fset.Position(call.Pos()).Line
-	endLine := fset.Position(call.End()).Line
-	nextT := ""
-	if callback != nil && callback.Body != nil {
-		startLine = fset.Position(callback.Body.Pos()).Line
-		endLine = fset.Position(callback.Body.End()).Line
-		nextT, _ = extractTestingParamName(callback.Type, testingAliases, testingDot)
+func (s *scanner) matchSubtestCall(call *ast.CallExpr, sub subtestCall) (*Scope, tEnv, subtestCallback) {
+	name, resolvable := evalString(sub.name, s.eval)
+	callback, hasCallback := s.resolveCallback(sub.callback)
+	startLine := s.fset.Position(call.Pos()).Line
+	endLine := s.fset.Position(call.End()).Line
+	var nextEnv tEnv
+	if hasCallback {
+		startLine = s.fset.Position(callback.body.Pos()).Line
+		endLine = s.fset.Position(callback.body.End()).Line
+		candidates := []paramType{sub.typ}
+		if sub.recv == nil {
+			candidates = receiverTypes
+		}
+		for _, typ := range candidates {
+			if typ == suiteReceiver {
+				if recv, ok := sub.recv.(*ast.Ident); ok && callback.callee == "" && callback.typ.Params.NumFields() == 0 {
+					nextEnv = tEnv{recv.Name: typ}
+				}
+				break
+			}
+			if nextT, ok := s.extractParamName(callback.typ, typ); ok {
+				if nextT != "" {
+					nextEnv = tEnv{nextT: typ}
+				}
+				break
+			}
+		}
 	}
 
 	child := &Scope{
@@ -98,33 +396,253 @@ func matchSubtestCall(call *ast.CallExpr, currentT string, fset *token.FileSet,
 		StartLine:      startLine,
 		EndLine:        endLine,
 		NameResolvable: resolvable,
+		Callee:         callback.callee,
 	}
 	if !resolvable {
 		child.Name = ""
+		child.Alternatives = loopAlternatives(sub.name, s.eval)
+	}
+	if !hasCallback {
+		if filename := s.fset.Position(call.Pos()).Filename; filename != s.filename {
+			child.File = filename
+		}
+		return child, nil, subtestCallback{}
+	}
+	if filename := s.fset.Position(callback.body.Pos()).Filename; filename != s.filename {
+		child.File = filename
+	}
+	return child, nextEnv, callback
+}
+
+func (s *scanner) typedReceiver(sel *ast.SelectorExpr, env tEnv) (paramType, bool) {
+	if s.eval == nil || s.eval.info == nil {
+		return paramType{}, false
+	}
+	fn, ok := s.eval.info.Uses[sel.Sel].(*types.Func)
+	if !ok || fn.Signature().Recv() == nil {
+		return paramType{}, false
+	}
+	typ, ok := frameworkType(fn.Signature().Recv().Type())
+	if !ok {
+		return paramType{}, false
+	}
+	if root := rootIdent(sel.X); root != nil {
+		if bound, ok := env[root.Name]; ok && bound == shadowedT {
+			return paramType{}, false
+		}
+	}
+	return typ, true
+}
+
+func (s *scanner) exprReceiver(expr ast.Expr, env tEnv) (paramType, bool) {
+	if s.eval != nil && s.eval.info != nil {
+		if tv, ok := s.eval.info.Types[expr]; ok && tv.Type != nil {
+			if typ, ok := frameworkType(tv.Type); ok {
+				if root := rootIdent(expr); root != nil && env[root.Name] == shadowedT {
+					return paramType{}, false
+				}
+				return typ, true
+			}
+		}
+	}
+	return s.receiverType(expr, env)
+}
+
+func frameworkType(t types.Type) (paramType, bool) {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
 	}
-	if callback == nil {
-		return child, "", nil
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return paramType{}, false
 	}
-	return child, nextT, callback.Body
+	for _, typ := range receiverTypes {
+		if named.Obj().Pkg().Path() == typ.importPath && named.Obj().Name() == typ.name {
+			return typ, true
+		}
+	}
+	return paramType{}, false
 }
 
-func isTopLevelTestName(name string) bool {
-	if !strings.HasPrefix(name, "Test") {
+func rootIdent(expr ast.Expr) *ast.Ident {
+	for {
+		switch e := expr.(type) {
+		case *ast.Ident:
+			return e
+		case *ast.SelectorExpr:
+			expr = e.X
+		case *ast.ParenExpr:
+			expr = e.X
+		case *ast.TypeAssertExpr:
+			expr = e.X
+		case *ast.StarExpr:
+			expr = e.X
+		case *ast.IndexExpr:
+			expr = e.X
+		case *ast.CallExpr:
+			expr = e.Fun
+		default:
+			return nil
+		}
+	}
+}
+
+func (s *scanner) resolveCallback(expr ast.Expr) (subtestCallback, bool) {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.FuncLit:
+		if e.Body == nil {
+			return subtestCallback{}, false
+		}
+		return subtestCallback{typ: e.Type, body: e.Body}, true
+	case *ast.Ident, *ast.SelectorExpr:
+		if ident, ok := e.(*ast.Ident); ok && s.eval != nil && s.eval.info != nil {
+			if cb, ok := s.callbacks[s.eval.info.Uses[ident]]; ok {
+				return cb, true
+			}
+		}
+		if ident, lit := s.lambda(e); lit != nil {
+			return subtestCallback{typ: lit.Type, body: lit.Body, callee: ident.Name}, true
+		}
+		decl := s.funcDecl(e)
+		if decl == nil {
+			return subtestCallback{}, false
+		}
+		return subtestCallback{typ: decl.Type, body: decl.Body, callee: decl.Name.Name}, true
+	case *ast.CallExpr:
+		decl := s.funcDecl(e.Fun)
+		if decl == nil {
+			return subtestCallback{}, false
+		}
+		lit := returnedFuncLit(decl.Body)
+		if lit == nil {
+			return subtestCallback{}, false
+		}
+		return subtestCallback{typ: lit.Type, body: lit.Body, callee: decl.Name.Name}, true
+	}
+	return subtestCallback{}, false
+}
+
+func (s *scanner) funcDecl(expr ast.Expr) *ast.FuncDecl {
+	if s.eval == nil || s.eval.info == nil {
+		return nil
+	}
+	var ident *ast.Ident
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		ident = e
+	case *ast.SelectorExpr:
+		ident = e.Sel
+	default:
+		return nil
+	}
+	fn, ok := s.eval.info.Uses[ident].(*types.Func)
+	if !ok {
+		return nil
+	}
+	return s.eval.funcs[fn]
+}
+
+func returnedFuncLit(body *ast.BlockStmt) *ast.FuncLit {
+	for i := len(body.List) - 1; i >= 0; i-- {
+		ret, ok := body.List[i].(*ast.ReturnStmt)
+		if !ok || len(ret.Results) != 1 {
+			continue
+		}
+		if lit, ok := ret.Results[0].(*ast.FuncLit); ok && lit.Body != nil {
+			return lit
+		}
+		return nil
+	}
+	return nil
+}
+
+func (s *scanner) scanFuzzSeeds(stmts []ast.Stmt, fVar string, parent *Scope) {
+	index := 0
+	ordered := true
+	for _, stmt := range stmts {
+		if expr, ok := stmt.(*ast.ExprStmt); ok {
+			if call, ok := expr.X.(*ast.CallExpr); ok && isMethodCall(call, fVar, "Add") {
+				seed := &Scope{
+					Name:           fmt.Sprintf("seed#%d", index),
+					Kind:           ScopeKindSeed,
+					StartLine:      s.fset.Position(call.Pos()).Line,
+					EndLine:        s.fset.Position(call.End()).Line,
+					NameResolvable: ordered,
+					Parent:         parent,
+				}
+				if !ordered {
+					seed.Name = ""
+				}
+				parent.Children = append(parent.Children, seed)
+				index++
+				continue
+			}
+		}
+		ast.Inspect(stmt, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.FuncLit:
+				return false
+			case *ast.CallExpr:
+				if isMethodCall(node, fVar, "Add") {
+					ordered = false
+					parent.Children = append(parent.Children, &Scope{
+						Kind:      ScopeKindSeed,
+						StartLine: s.fset.Position(node.Pos()).Line,
+						EndLine:   s.fset.Position(node.End()).Line,
+						Parent:    parent,
+					})
+				}
+			}
+			return true
+		})
+	}
+}
+
+func isMethodCall(call *ast.CallExpr, recvName string, method string) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != method {
+		return false
+	}
+	recv, ok := sel.X.(*ast.Ident)
+	return ok && recv.Name == recvName
+}
+
+func examplesWithOutput(file *ast.File) map[string]bool {
+	out := make(map[string]bool)
+	for _, ex := range doc.Examples(file) {
+		if ex.Output != "" || ex.EmptyOutput {
+			out["Example"+ex.Name] = true
+		}
+	}
+	return out
+}
+
+func matchTopLevelName(name string) (topLevelKind, bool) {
+	for _, top := range topLevelKinds {
+		if hasTopLevelPrefix(name, top.prefix) {
+			return top, true
+		}
+	}
+	return topLevelKind{}, false
+}
+
+func hasTopLevelPrefix(name string, prefix string) bool {
+	if !strings.HasPrefix(name, prefix) {
 		return false
 	}
-	if len(name) == len("Test") {
+	if len(name) == len(prefix) {
 		return true
 	}
-	r, _ := utf8.DecodeRuneInString(name[len("Test"):])
+	r, _ := utf8.DecodeRuneInString(name[len(prefix):])
 	return !unicode.IsLower(r)
 }
 
-func extractTestingParamName(ft *ast.FuncType, aliases map[string]bool, dot bool) (string, bool) {
+func (s *scanner) extractParamName(ft *ast.FuncType, typ paramType) (string, bool) {
 	if ft == nil || ft.Params == nil || len(ft.Params.List) != 1 {
 		return "", false
 	}
 	param := ft.Params.List[0]
-	if !isTestingTType(param.Type, aliases, dot) {
+	if !s.isParamType(param.Type, typ) {
 		return "", false
 	}
 	if len(param.Names) == 0 {
@@ -137,20 +655,28 @@ func extractTestingParamName(ft *ast.FuncType, aliases map[string]bool, dot bool
 	return name, true
 }
 
-func isTestingTType(expr ast.Expr, aliases map[string]bool, dot bool) bool {
+func (s *scanner) isParamType(expr ast.Expr, typ paramType) bool {
 	star, ok := expr.(*ast.StarExpr)
 	if !ok {
 		return false
 	}
+	if s.eval != nil && s.eval.info != nil {
+		if tv, ok := s.eval.info.Types[star.X]; ok && tv.IsType() {
+			if found, ok := frameworkType(tv.Type); ok {
+				return found == typ
+			}
+		}
+	}
+	a := s.aliases(typ.importPath)
 	switch x := star.X.(type) {
 	case *ast.SelectorExpr:
 		ident, ok := x.X.(*ast.Ident)
 		if !ok {
 			return false
 		}
-		return aliases[ident.Name] && x.Sel.Name == "T"
+		return a.names[ident.Name] && x.Sel.Name == typ.name
 	case *ast.Ident:
-		return dot && x.Name == "T"
+		return a.dot && x.Name == typ.name
 	default:
 		return false
 	}
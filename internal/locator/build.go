@@ -0,0 +1,162 @@
+package locator
+
+import (
+	"fmt"
+	"go/build"
+	"go/build/constraint"
+	"go/parser"
+	"go/token"
+	"math/bits"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+const maxInferredTagCandidates = 12
+
+var knownOS = []string{
+	"aix", "android", "darwin", "dragonfly", "freebsd", "hurd", "illumos", "ios", "js",
+	"linux", "nacl", "netbsd", "openbsd", "plan9", "solaris", "wasip1", "windows", "zos",
+}
+
+var unixOS = []string{
+	"aix", "android", "darwin", "dragonfly", "freebsd", "hurd", "illumos", "ios",
+	"linux", "netbsd", "openbsd", "solaris",
+}
+
+var knownArch = []string{
+	"386", "amd64", "amd64p32", "arm", "armbe", "arm64", "arm64be", "loong64", "mips",
+	"mipsle", "mips64", "mips64le", "mips64p32", "mips64p32le", "ppc", "ppc64", "ppc64le",
+	"riscv", "riscv64", "s390", "s390x", "sparc", "sparc64", "wasm",
+}
+
+func buildContext(opts ResolveOptions) build.Context {
+	ctxt := build.Default
+	if opts.GOOS != "" {
+		ctxt.GOOS = opts.GOOS
+	}
+	if opts.GOARCH != "" {
+		ctxt.GOARCH = opts.GOARCH
+	}
+	if (ctxt.GOOS != runtime.GOOS || ctxt.GOARCH != runtime.GOARCH) && os.Getenv("CGO_ENABLED") == "" {
+		ctxt.CgoEnabled = false
+	}
+	ctxt.BuildTags = append([]string(nil), opts.BuildTags...)
+	return ctxt
+}
+
+func inferFileTags(filePath string, opts ResolveOptions) ([]string, string) {
+	expr, text := fileConstraint(filePath)
+	if expr == nil {
+		return nil, ""
+	}
+	tags, ok := inferBuildTags(expr, buildContext(opts))
+	if !ok || len(tags) == 0 {
+		return nil, ""
+	}
+	return tags, fmt.Sprintf("inferred -tags %s from //go:build %s in %s", strings.Join(tags, ","), text, filepath.Base(filePath))
+}
+
+func fileConstraint(filePath string) (constraint.Expr, string) {
+	f, err := parser.ParseFile(token.NewFileSet(), filePath, nil, parser.PackageClauseOnly|parser.ParseComments)
+	if err != nil {
+		return nil, ""
+	}
+	for _, group := range f.Comments {
+		if group.Pos() > f.Package {
+			break
+		}
+		for _, c := range group.List {
+			if !constraint.IsGoBuild(c.Text) {
+				continue
+			}
+			expr, err := constraint.Parse(c.Text)
+			if err != nil {
+				return nil, ""
+			}
+			return expr, strings.TrimSpace(strings.TrimPrefix(c.Text, "//go:build"))
+		}
+	}
+	return nil, ""
+}
+
+func inferBuildTags(expr constraint.Expr, ctxt build.Context) ([]string, bool) {
+	if expr.Eval(func(tag string) bool { return matchTag(ctxt, tag) }) {
+		return nil, true
+	}
+	var candidates []string
+	collectTags(expr, func(tag string) {
+		if isSettableTag(tag) && !slices.Contains(candidates, tag) {
+			candidates = append(candidates, tag)
+		}
+	})
+	if len(candidates) > maxInferredTagCandidates {
+		return nil, false
+	}
+	for size := 1; size <= len(candidates); size++ {
+		for mask := 1; mask < 1<<len(candidates); mask++ {
+			if bits.OnesCount(uint(mask)) != size {
+				continue
+			}
+			var tags []string
+			for i, tag := range candidates {
+				if mask&(1<<i) != 0 {
+					tags = append(tags, tag)
+				}
+			}
+			if expr.Eval(func(tag string) bool { return slices.Contains(tags, tag) || matchTag(ctxt, tag) }) {
+				return tags, true
+			}
+		}
+	}
+	return nil, false
+}
+
+func collectTags(expr constraint.Expr, visit func(string)) {
+	switch e := expr.(type) {
+	case *constraint.TagExpr:
+		visit(e.Tag)
+	case *constraint.NotExpr:
+		collectTags(e.X, visit)
+	case *constraint.AndExpr:
+		collectTags(e.X, visit)
+		collectTags(e.Y, visit)
+	case *constraint.OrExpr:
+		collectTags(e.X, visit)
+		collectTags(e.Y, visit)
+	}
+}
+
+func isSettableTag(tag string) bool {
+	switch {
+	case slices.Contains(knownOS, tag), slices.Contains(knownArch, tag):
+		return false
+	case tag == "unix", tag == "cgo", tag == "gc", tag == "gccgo", tag == "ignore":
+		return false
+	case strings.HasPrefix(tag, "go1."), strings.HasPrefix(tag, "goexperiment."):
+		return false
+	case strings.HasPrefix(tag, "go") && strings.Contains(tag, "."):
+		return false
+	}
+	return true
+}
+
+func matchTag(ctxt build.Context, tag string) bool {
+	switch {
+	case tag == ctxt.GOOS, tag == ctxt.GOARCH, tag == ctxt.Compiler:
+		return true
+	case tag == "linux" && ctxt.GOOS == "android":
+		return true
+	case tag == "solaris" && ctxt.GOOS == "illumos":
+		return true
+	case tag == "darwin" && ctxt.GOOS == "ios":
+		return true
+	case tag == "unix":
+		return slices.Contains(unixOS, ctxt.GOOS)
+	case tag == "cgo":
+		return ctxt.CgoEnabled
+	}
+	return slices.Contains(ctxt.BuildTags, tag) || slices.Contains(ctxt.ToolTags, tag) || slices.Contains(ctxt.ReleaseTags, tag)
+}
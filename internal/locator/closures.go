@@ -0,0 +1,288 @@
+package locator
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"maps"
+)
+
+type funcSpan struct {
+	pos, end token.Pos
+}
+
+func indexLambdas(files []*ast.File, info *types.Info) map[types.Object]*ast.FuncLit {
+	lambdas := make(map[types.Object]*ast.FuncLit)
+	reassigned := make(map[types.Object]bool)
+	define := func(ident *ast.Ident, value ast.Expr) {
+		lit, ok := ast.Unparen(value).(*ast.FuncLit)
+		if !ok || lit.Body == nil || ident.Name == "_" {
+			return
+		}
+		obj, ok := info.Defs[ident].(*types.Var)
+		if !ok || obj.Parent() == nil || obj.Parent() == obj.Pkg().Scope() {
+			return
+		}
+		lambdas[obj] = lit
+	}
+	for _, file := range files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.ValueSpec:
+				if len(node.Names) == len(node.Values) {
+					for i, name := range node.Names {
+						define(name, node.Values[i])
+					}
+				}
+			case *ast.AssignStmt:
+				for i, lhs := range node.Lhs {
+					ident, ok := ast.Unparen(lhs).(*ast.Ident)
+					if !ok {
+						continue
+					}
+					if obj := info.Uses[ident]; obj != nil {
+						reassigned[obj] = true
+						continue
+					}
+					if node.Tok == token.DEFINE && len(node.Lhs) == len(node.Rhs) {
+						define(ident, node.Rhs[i])
+					}
+				}
+			case *ast.UnaryExpr:
+				if ident, ok := ast.Unparen(node.X).(*ast.Ident); ok && node.Op == token.AND {
+					reassigned[info.Uses[ident]] = true
+				}
+			}
+			return true
+		})
+	}
+	for obj := range reassigned {
+		delete(lambdas, obj)
+	}
+	return lambdas
+}
+
+func (s *scanner) lambda(expr ast.Expr) (*ast.Ident, *ast.FuncLit) {
+	if s.eval == nil || s.eval.info == nil {
+		return nil, nil
+	}
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+	if !ok {
+		return nil, nil
+	}
+	lit := s.eval.lambdas[s.eval.info.Uses[ident]]
+	if lit == nil {
+		return nil, nil
+	}
+	return ident, lit
+}
+
+func (s *scanner) isLambda(lit *ast.FuncLit) bool {
+	if s.eval == nil {
+		return false
+	}
+	for _, l := range s.eval.lambdas {
+		if l == lit {
+			return true
+		}
+	}
+	return false
+}
+
+func closureEnv(ft *ast.FuncType, env tEnv) tEnv {
+	inner := copyEnv(env)
+	for _, fields := range []*ast.FieldList{ft.Params, ft.Results} {
+		if fields == nil {
+			continue
+		}
+		for _, field := range fields.List {
+			for _, name := range field.Names {
+				inner[name.Name] = shadowedT
+			}
+		}
+	}
+	return inner
+}
+
+func (s *scanner) scanLambdaCall(call *ast.CallExpr, env tEnv, parent *Scope) {
+	ident, lit := s.lambda(call.Fun)
+	if lit == nil {
+		return
+	}
+	s.enterFunc(lit.Type, lit.Body)
+	s.scanCallee(call, lit.Type, lit.Body, s.eval, closureEnv(lit.Type, env), env, parent, ident.Name)
+	s.leaveFunc()
+}
+
+func (s *scanner) enterFunc(typ *ast.FuncType, body *ast.BlockStmt) {
+	s.funcs = append(s.funcs, funcSpan{pos: typ.Pos(), end: body.End()})
+}
+
+func (s *scanner) leaveFunc() {
+	s.funcs = s.funcs[:len(s.funcs)-1]
+}
+
+func (s *scanner) ownerScope(recv ast.Expr, parent *Scope) *Scope {
+	if len(s.funcs) == 0 || s.eval == nil || s.eval.info == nil {
+		return parent
+	}
+	root := rootIdent(recv)
+	if root == nil {
+		return parent
+	}
+	obj := s.eval.info.Uses[root]
+	fn := s.funcs[len(s.funcs)-1]
+	if obj == nil || (obj.Pos() >= fn.pos && obj.Pos() < fn.end) {
+		return parent
+	}
+	pos := s.fset.Position(obj.Pos())
+	if pos.Filename != s.filename {
+		return parent
+	}
+	for cur := parent; cur != nil; cur = cur.Parent {
+		if containsLine(cur, pos.Line) && inFile(cur, s.filename) {
+			return cur
+		}
+	}
+	return parent
+}
+
+func (s *scanner) scanHelperCall(call *ast.CallExpr, env tEnv, parent *Scope) {
+	decl, callee := s.helperDecl(call.Fun)
+	if decl == nil {
+		return
+	}
+	s.enterFunc(decl.Type, decl.Body)
+	s.scanCallee(call, decl.Type, decl.Body, callee, nil, env, parent, "")
+	s.leaveFunc()
+}
+
+func (s *scanner) helperDecl(fun ast.Expr) (*ast.FuncDecl, *evalContext) {
+	if s.eval == nil || s.eval.info == nil {
+		return nil, nil
+	}
+	if decl := s.funcDecl(fun); decl != nil {
+		return decl, s.eval
+	}
+	sel, ok := ast.Unparen(fun).(*ast.SelectorExpr)
+	if !ok {
+		return nil, nil
+	}
+	ident, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return nil, nil
+	}
+	pkgName, ok := s.eval.info.Uses[ident].(*types.PkgName)
+	if !ok {
+		return nil, nil
+	}
+	return s.eval.helpers.function(pkgName.Imported().Path(), sel.Sel.Name)
+}
+
+func (s *scanner) scanCallee(call *ast.CallExpr, ft *ast.FuncType, body *ast.BlockStmt, callee *evalContext, calleeEnv tEnv, env tEnv, parent *Scope, name string) {
+	if s.calleeDepth >= maxValueDepth || call.Ellipsis != token.NoPos {
+		return
+	}
+	lambda := calleeEnv != nil
+	if !lambda {
+		calleeEnv = tEnv{}
+	}
+	combos := []loopBindings{{}}
+	callbacks := make(map[types.Object]subtestCallback)
+	receivers := 0
+	arg := 0
+	for _, field := range ft.Params.List {
+		if _, variadic := field.Type.(*ast.Ellipsis); variadic {
+			break
+		}
+		for _, param := range field.Names {
+			if arg >= len(call.Args) {
+				return
+			}
+			value := call.Args[arg]
+			arg++
+			obj := callee.info.Defs[param]
+			if obj == nil || param.Name == "_" {
+				continue
+			}
+			if typ, ok := s.exprReceiver(value, env); ok {
+				calleeEnv[param.Name] = typ
+				receivers++
+				continue
+			}
+			if _, isFunc := obj.Type().Underlying().(*types.Signature); isFunc {
+				if cb, ok := s.resolveCallback(value); ok {
+					if cb.eval == nil {
+						cb.eval = s.eval
+					}
+					callbacks[obj] = cb
+				}
+				continue
+			}
+			if v, ok := elementValue(value, s.eval); ok {
+				for _, combo := range combos {
+					combo[obj] = v
+				}
+				continue
+			}
+			alternatives := loopAlternatives(value, s.eval)
+			if len(alternatives) == 0 || len(combos)*len(alternatives) > maxLoopIterations {
+				continue
+			}
+			next := make([]loopBindings, 0, len(combos)*len(alternatives))
+			for _, combo := range combos {
+				for _, alt := range alternatives {
+					merged := make(loopBindings, len(combo)+1)
+					for o, v := range combo {
+						merged[o] = v
+					}
+					merged[obj] = constant.MakeString(alt)
+					next = append(next, merged)
+				}
+			}
+			combos = next
+		}
+	}
+	if !lambda && receivers == 0 {
+		return
+	}
+
+	outer := s.eval
+	outerCallbacks := s.callbacks
+	s.callbacks = maps.Clone(outerCallbacks)
+	maps.Copy(s.callbacks, callbacks)
+	s.calleeDepth++
+	defer func() {
+		s.eval = outer
+		s.callbacks = outerCallbacks
+		s.calleeDepth--
+	}()
+	for _, combo := range combos {
+		inner := *callee
+		inner.bindings = make(map[types.Object]constant.Value, len(callee.bindings)+len(combo))
+		for obj, v := range callee.bindings {
+			inner.bindings[obj] = v
+		}
+		for obj, v := range combo {
+			inner.bindings[obj] = v
+		}
+		s.eval = &inner
+		before := len(parent.Children)
+		s.scanStmtList(body.List, copyEnv(calleeEnv), parent)
+		if name == "" {
+			continue
+		}
+		for _, child := range parent.Children[before:] {
+			if child.Callee == "" {
+				child.Callee = name
+			}
+		}
+	}
+}
+
+func copyEnv(env tEnv) tEnv {
+	inner := make(tEnv, len(env))
+	maps.Copy(inner, env)
+	return inner
+}
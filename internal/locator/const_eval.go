@@ -3,84 +3,218 @@ package locator
 import (
 	"fmt"
 	"go/ast"
+	"go/build"
 	"go/constant"
-	"go/importer"
 	"go/parser"
 	"go/token"
 	"go/types"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 
 	"github.com/loheagn/gun/internal/errs"
+	"github.com/loheagn/gun/internal/project"
 )
 
 type evalContext struct {
-	info           *types.Info
-	fmtAliases     map[string]bool
-	fmtDot         bool
-	strconvAliases map[string]bool
-	strconvDot     bool
+	info       *types.Info
+	values     map[types.Object]ast.Expr
+	rangeStmts map[types.Object]*ast.RangeStmt
+	forStmts   map[types.Object]*ast.ForStmt
+	locals     map[types.Object]*localVar
+	funcs      map[types.Object]*ast.FuncDecl
+	lambdas    map[types.Object]*ast.FuncLit
+	helpers    *helperLoader
+	bindings   map[types.Object]constant.Value
+	depth      int
 }
 
-func loadPackageTypes(filePath string) (*token.FileSet, *ast.File, *types.Info, error) {
+type loadedPackage struct {
+	fset     *token.FileSet
+	target   *ast.File
+	files    []*ast.File
+	internal []*ast.File
+	info     *types.Info
+	importer types.Importer
+	build    build.Context
+}
+
+func loadPackageTypes(filePath string, opts ResolveOptions) (*loadedPackage, error) {
 	absFile, err := filepath.Abs(filePath)
 	if err != nil {
-		return nil, nil, nil, errs.New(errs.CodeUsage, "failed to resolve target file", err)
+		return nil, errs.New(errs.CodeUsage, "failed to resolve target file", err)
 	}
 	absFile = filepath.Clean(absFile)
 
 	probeSet := token.NewFileSet()
 	probeFile, err := parser.ParseFile(probeSet, absFile, nil, 0)
 	if err != nil {
-		return nil, nil, nil, errs.New(errs.CodeUsage, "failed to parse target file", err)
+		return nil, errs.New(errs.CodeUsage, "failed to parse target file", err)
 	}
 
 	dir := filepath.Dir(absFile)
 	entries, err := os.ReadDir(dir)
 	if err != nil {
-		return nil, nil, nil, errs.New(errs.CodeUsage, "failed to read package directory", err)
+		return nil, errs.New(errs.CodeUsage, "failed to read package directory", err)
 	}
 
+	ctxt := buildContext(opts)
 	fset := token.NewFileSet()
-	var files []*ast.File
+	packages := make(map[string][]*ast.File)
 	var target *ast.File
 	for _, entry := range entries {
 		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") {
 			continue
 		}
 		path := filepath.Join(dir, entry.Name())
-		f, parseErr := parser.ParseFile(fset, path, nil, 0)
+		if match, err := ctxt.MatchFile(dir, entry.Name()); err == nil && !match {
+			if filepath.Clean(path) == absFile {
+				return nil, errs.New(errs.CodeUsage, fmt.Sprintf("%s is excluded by build constraints for %s/%s; pass -tags or --goos/--goarch", entry.Name(), ctxt.GOOS, ctxt.GOARCH), nil)
+			}
+			continue
+		}
+		f, parseErr := parser.ParseFile(fset, path, nil, parser.ParseComments)
 		if parseErr != nil {
 			continue
 		}
-		if f.Name == nil || f.Name.Name != probeFile.Name.Name {
+		if f.Name == nil {
 			continue
 		}
-		files = append(files, f)
-		if filepath.Clean(path) == absFile {
+		packages[f.Name.Name] = append(packages[f.Name.Name], f)
+		if filepath.Clean(path) == absFile && f.Name.Name == probeFile.Name.Name {
 			target = f
 		}
 	}
 
 	if target == nil {
-		return nil, nil, nil, errs.New(errs.CodeUsage, fmt.Sprintf("target file %q not in package set", absFile), nil)
+		return nil, errs.New(errs.CodeUsage, fmt.Sprintf("target file %q not in package set", absFile), nil)
+	}
+
+	imp := newPackageImporter(fset, dir, ctxt)
+	pkg := &loadedPackage{fset: fset, target: target, files: packages[probeFile.Name.Name], importer: imp, build: ctxt}
+	path, err := project.ImportPath(dir)
+	if err != nil {
+		pkg.info = checkFiles(probeFile.Name.Name, fset, pkg.files, imp)
+		return pkg, nil
+	}
+	if name, ok := strings.CutSuffix(probeFile.Name.Name, "_test"); ok && len(packages[name]) > 0 {
+		pkg.internal = packages[name]
+		pkg.info = newTypesInfo()
+		internal := checkInto(path, fset, pkg.internal, imp, pkg.info)
+		checkInto(path+"_test", fset, pkg.files, &sourceImporter{pkg: internal, next: imp}, pkg.info)
+		return pkg, nil
+	}
+	pkg.info = checkFiles(path, fset, pkg.files, imp)
+	return pkg, nil
+}
+
+func checkFiles(path string, fset *token.FileSet, files []*ast.File, imp types.Importer) *types.Info {
+	info := newTypesInfo()
+	checkInto(path, fset, files, imp, info)
+	return info
+}
+
+func checkInto(path string, fset *token.FileSet, files []*ast.File, imp types.Importer, info *types.Info) *types.Package {
+	cfg := types.Config{
+		Importer: imp,
+		Error:    func(error) {},
 	}
+	pkg, _ := cfg.Check(path, fset, files, info)
+	return pkg
+}
 
-	info := &types.Info{
+func newTypesInfo() *types.Info {
+	return &types.Info{
 		Types: make(map[ast.Expr]types.TypeAndValue),
 		Defs:  make(map[*ast.Ident]types.Object),
 		Uses:  make(map[*ast.Ident]types.Object),
 	}
+}
+
+func newEvalContext(pkg *loadedPackage) *evalContext {
+	files := append(slices.Clone(pkg.files), pkg.internal...)
+	values, rangeStmts := indexValues(files, pkg.info)
+	return &evalContext{
+		info:       pkg.info,
+		values:     values,
+		rangeStmts: rangeStmts,
+		forStmts:   indexForLoops(files, pkg.info),
+		locals:     indexLocals(files, pkg.info),
+		funcs:      indexFuncs(files, pkg.info),
+		lambdas:    indexLambdas(files, pkg.info),
+		helpers:    newHelperLoader(pkg.fset, filepath.Dir(pkg.fset.Position(pkg.target.Pos()).Filename), pkg.importer, pkg.build),
+	}
+}
 
-	cfg := types.Config{
-		Importer: importer.Default(),
-		Error:    func(error) {},
+func indexFuncs(files []*ast.File, info *types.Info) map[types.Object]*ast.FuncDecl {
+	funcs := make(map[types.Object]*ast.FuncDecl)
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Body == nil {
+				continue
+			}
+			if obj := info.Defs[fn.Name]; obj != nil {
+				funcs[obj] = fn
+			}
+		}
 	}
-	_, _ = cfg.Check(probeFile.Name.Name, fset, files, info)
+	return funcs
+}
 
-	return fset, target, info, nil
+func indexValues(files []*ast.File, info *types.Info) (map[types.Object]ast.Expr, map[types.Object]*ast.RangeStmt) {
+	values := make(map[types.Object]ast.Expr)
+	ranges := make(map[types.Object]*ast.RangeStmt)
+	define := func(ident *ast.Ident, value ast.Expr) {
+		if ident == nil || ident.Name == "_" {
+			return
+		}
+		obj := info.Defs[ident]
+		if obj == nil {
+			return
+		}
+		if _, seen := values[obj]; !seen {
+			values[obj] = value
+		}
+	}
+	for _, file := range files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.ValueSpec:
+				if len(node.Names) != len(node.Values) {
+					return true
+				}
+				for i, name := range node.Names {
+					define(name, node.Values[i])
+				}
+			case *ast.AssignStmt:
+				if node.Tok != token.DEFINE || len(node.Lhs) != len(node.Rhs) {
+					return true
+				}
+				for i, lhs := range node.Lhs {
+					ident, _ := lhs.(*ast.Ident)
+					define(ident, node.Rhs[i])
+				}
+			case *ast.RangeStmt:
+				if node.Tok != token.DEFINE {
+					return true
+				}
+				for _, expr := range []ast.Expr{node.Key, node.Value} {
+					ident, ok := expr.(*ast.Ident)
+					if !ok || ident.Name == "_" {
+						continue
+					}
+					if obj := info.Defs[ident]; obj != nil {
+						ranges[obj] = node
+					}
+				}
+			}
+			return true
+		})
+	}
+	return values, ranges
 }
 
 func collectImportAliases(file *ast.File, importPath string) (map[string]bool, bool) {
@@ -92,11 +226,7 @@ func collectImportAliases(file *ast.File, importPath string) (map[string]bool, b
 			continue
 		}
 		if spec.Name == nil {
-			base := importPath
-			if idx := strings.LastIndex(importPath, "/"); idx >= 0 {
-				base = importPath[idx+1:]
-			}
-			aliases[base] = true
+			aliases[defaultImportName(importPath)] = true
 			continue
 		}
 		switch spec.Name.Name {
@@ -111,6 +241,26 @@ func collectImportAliases(file *ast.File, importPath string) (map[string]bool, b
 	return aliases, dot
 }
 
+func defaultImportName(importPath string) string {
+	elems := strings.Split(importPath, "/")
+	base := elems[len(elems)-1]
+	if len(elems) > 1 && isMajorVersion(base) {
+		base = elems[len(elems)-2]
+	}
+	if idx := strings.Index(base, ".v"); idx > 0 && isMajorVersion(base[idx+1:]) {
+		base = base[:idx]
+	}
+	return base
+}
+
+func isMajorVersion(elem string) bool {
+	if len(elem) < 2 || elem[0] != 'v' {
+		return false
+	}
+	_, err := strconv.Atoi(elem[1:])
+	return err == nil
+}
+
 func evalString(expr ast.Expr, ctx *evalContext) (string, bool) {
 	if v, ok := constValue(expr, ctx); ok && v.Kind() == constant.String {
 		return constant.StringVal(v), true
@@ -126,6 +276,8 @@ func evalString(expr ast.Expr, ctx *evalContext) (string, bool) {
 			return "", false
 		}
 		return v, true
+	case *ast.Ident:
+		return localString(e, ctx)
 	case *ast.ParenExpr:
 		return evalString(e.X, ctx)
 	case *ast.BinaryExpr:
@@ -141,34 +293,19 @@ func evalString(expr ast.Expr, ctx *evalContext) (string, bool) {
 			return "", false
 		}
 		return lhs + rhs, true
+	case *ast.IndexExpr:
+		if elem := indexedElement(e, ctx); elem != nil {
+			return evalString(elem, ctx)
+		}
 	case *ast.CallExpr:
-		if isFmtSprintfCall(e.Fun, ctx) {
-			if len(e.Args) < 1 {
-				return "", false
-			}
-			format, ok := evalString(e.Args[0], ctx)
-			if !ok {
-				return "", false
-			}
-			vals := make([]any, 0, len(e.Args)-1)
-			for _, arg := range e.Args[1:] {
-				v, ok := evalAny(arg, ctx)
-				if !ok {
-					return "", false
-				}
-				vals = append(vals, v)
-			}
-			return fmt.Sprintf(format, vals...), true
+		if fold, ok := lookupStringFunc(e.Fun, ctx); ok {
+			return fold.call(e, ctx)
 		}
-		if isStrconvItoaCall(e.Fun, ctx) {
-			if len(e.Args) != 1 {
-				return "", false
-			}
-			i, ok := evalInt(e.Args[0], ctx)
-			if !ok {
-				return "", false
-			}
-			return strconv.Itoa(int(i)), true
+		if target, ok := conversionType(e, ctx); ok {
+			return evalStringConversion(target, e.Args[0], ctx)
+		}
+		if sel, ok := e.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "String" && len(e.Args) == 0 {
+			return evalStringMethod(sel.X, ctx)
 		}
 	}
 
@@ -176,6 +313,13 @@ func evalString(expr ast.Expr, ctx *evalContext) (string, bool) {
 }
 
 func evalAny(expr ast.Expr, ctx *evalContext) (any, bool) {
+	if hasStringMethod(expr, ctx) {
+		return evalStringer(expr, ctx)
+	}
+	return evalPlain(expr, ctx)
+}
+
+func evalPlain(expr ast.Expr, ctx *evalContext) (any, bool) {
 	if s, ok := evalString(expr, ctx); ok {
 		return s, true
 	}
@@ -192,14 +336,49 @@ func evalAny(expr ast.Expr, ctx *evalContext) (any, bool) {
 }
 
 func evalInt(expr ast.Expr, ctx *evalContext) (int64, bool) {
-	if v, ok := constValue(expr, ctx); ok {
+	if v, ok := constValue(expr, ctx); ok && v.Kind() == constant.Int {
 		if i, ok := constant.Int64Val(v); ok {
 			return i, true
 		}
 	}
 	switch e := expr.(type) {
+	case *ast.Ident:
+		return localInt(e, ctx)
 	case *ast.ParenExpr:
 		return evalInt(e.X, ctx)
+	case *ast.IndexExpr:
+		if elem := indexedElement(e, ctx); elem != nil {
+			return evalInt(elem, ctx)
+		}
+	case *ast.CallExpr:
+		if target, ok := conversionType(e, ctx); ok && target.Info()&types.IsInteger != 0 {
+			return evalInt(e.Args[0], ctx)
+		}
+	case *ast.BinaryExpr:
+		lhs, ok := evalInt(e.X, ctx)
+		if !ok {
+			return 0, false
+		}
+		rhs, ok := evalInt(e.Y, ctx)
+		if !ok {
+			return 0, false
+		}
+		switch e.Op {
+		case token.ADD:
+			return lhs + rhs, true
+		case token.SUB:
+			return lhs - rhs, true
+		case token.MUL:
+			return lhs * rhs, true
+		case token.QUO:
+			if rhs != 0 {
+				return lhs / rhs, true
+			}
+		case token.REM:
+			if rhs != 0 {
+				return lhs % rhs, true
+			}
+		}
 	case *ast.UnaryExpr:
 		v, ok := evalInt(e.X, ctx)
 		if !ok {
@@ -216,7 +395,7 @@ func evalInt(expr ast.Expr, ctx *evalContext) (int64, bool) {
 }
 
 func evalFloat(expr ast.Expr, ctx *evalContext) (float64, bool) {
-	if v, ok := constValue(expr, ctx); ok {
+	if v, ok := constValue(expr, ctx); ok && (v.Kind() == constant.Int || v.Kind() == constant.Float) {
 		if f, ok := constant.Float64Val(v); ok {
 			return f, true
 		}
@@ -242,6 +421,9 @@ func constValue(expr ast.Expr, ctx *evalContext) (constant.Value, bool) {
 	if !ok {
 		return nil, false
 	}
+	if v, ok := ctx.bindings[ctx.info.Uses[ident]]; ok {
+		return v, true
+	}
 	if obj, ok := ctx.info.Uses[ident].(*types.Const); ok {
 		return obj.Val(), true
 	}
@@ -250,39 +432,3 @@ func constValue(expr ast.Expr, ctx *evalContext) (constant.Value, bool) {
 	}
 	return nil, false
 }
-
-func isFmtSprintfCall(fun ast.Expr, ctx *evalContext) bool {
-	switch f := fun.(type) {
-	case *ast.SelectorExpr:
-		if f.Sel.Name != "Sprintf" {
-			return false
-		}
-		ident, ok := f.X.(*ast.Ident)
-		if !ok {
-			return false
-		}
-		return ctx != nil && ctx.fmtAliases[ident.Name]
-	case *ast.Ident:
-		return ctx != nil && ctx.fmtDot && f.Name == "Sprintf"
-	default:
-		return false
-	}
-}
-
-func isStrconvItoaCall(fun ast.Expr, ctx *evalContext) bool {
-	switch f := fun.(type) {
-	case *ast.SelectorExpr:
-		if f.Sel.Name != "Itoa" {
-			return false
-		}
-		ident, ok := f.X.(*ast.Ident)
-		if !ok {
-			return false
-		}
-		return ctx != nil && ctx.strconvAliases[ident.Name]
-	case *ast.Ident:
-		return ctx != nil && ctx.strconvDot && f.Name == "Itoa"
-	default:
-		return false
-	}
-}
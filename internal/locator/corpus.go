@@ -0,0 +1,149 @@
+package locator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/loheagn/gun/internal/errs"
+	"github.com/loheagn/gun/internal/project"
+)
+
+type CorpusEntry struct {
+	Target string
+	Path   string
+}
+
+func CorpusPackageDir(corpusFile string) string {
+	return filepath.Dir(filepath.Dir(filepath.Dir(filepath.Dir(corpusFile))))
+}
+
+func ResolveCorpus(mode Mode, corpusFile string, fuzzTarget string, entry string, opts ResolveOptions) (Resolution, error) {
+	pkgDir := CorpusPackageDir(corpusFile)
+	res := Resolution{
+		Mode:       mode,
+		Effective:  mode,
+		FilePath:   corpusFile,
+		PackageDir: pkgDir,
+		GOOS:       opts.GOOS,
+		GOARCH:     opts.GOARCH,
+	}
+
+	switch mode {
+	case ModePkg:
+		return res, nil
+	case ModeProject:
+		root, err := project.ResolveRoot(corpusFile, opts.ProjectRoot)
+		if err != nil {
+			return Resolution{}, err
+		}
+		res.ModuleRoot = root
+		return res, nil
+	case ModeRdeps:
+		return resolveReverseDeps(res, pkgDir, opts)
+	}
+
+	targets, err := packageFuzzTargets(pkgDir)
+	if err != nil {
+		return Resolution{}, err
+	}
+	if !targets[fuzzTarget] {
+		return Resolution{}, errs.New(errs.CodeUsage, fmt.Sprintf("fuzz target %q not found in package %q", fuzzTarget, pkgDir), nil)
+	}
+
+	switch mode {
+	case ModeLeaf, ModeAuto:
+		res.Effective = ModeLeaf
+		res.RunPattern = buildSegmentPattern([]string{fuzzTarget, entry})
+	case ModeParent, ModeTest, ModeFile:
+		res.Effective = ModeTest
+		res.RunPattern = buildSegmentPattern([]string{fuzzTarget})
+	case ModeFuzz:
+		res.RunPattern = buildSegmentPattern([]string{fuzzTarget})
+		res.FuzzPattern = res.RunPattern
+	default:
+		return Resolution{}, errs.New(errs.CodeUsage, fmt.Sprintf("unsupported mode %q", mode), nil)
+	}
+	return res, nil
+}
+
+func FuzzTargetsAt(filePath string, line int, opts ResolveOptions) ([]string, error) {
+	if tags, _ := inferFileTags(filePath, opts); len(tags) > 0 {
+		opts.BuildTags = append(slices.Clone(opts.BuildTags), tags...)
+	}
+	pkg, err := loadPackageTypes(filePath, opts)
+	if err != nil {
+		return nil, err
+	}
+	scan := scanTests(pkg.target, pkg.fset, nil, nil, nil)
+	fuzz := scopesOfKind(scan.Tests, ScopeKindFuzz)
+	if len(fuzz) == 0 {
+		return nil, errs.New(errs.CodeUsage, "no top-level FuzzXxx found in file", nil)
+	}
+	for _, scope := range fuzz {
+		if containsLine(scope, line) {
+			return []string{scope.Name}, nil
+		}
+	}
+	names := make([]string, 0, len(fuzz))
+	for _, scope := range fuzz {
+		names = append(names, scope.Name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func ListCorpus(pkgDir string, targets []string) ([]CorpusEntry, error) {
+	var entries []CorpusEntry
+	for _, target := range targets {
+		dir := filepath.Join(pkgDir, "testdata", "fuzz", target)
+		files, err := os.ReadDir(dir)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, errs.New(errs.CodeUsage, "failed to read fuzz corpus directory", err)
+		}
+		for _, file := range files {
+			if file.IsDir() {
+				continue
+			}
+			entries = append(entries, CorpusEntry{Target: target, Path: filepath.Join(dir, file.Name())})
+		}
+	}
+	return entries, nil
+}
+
+func packageFuzzTargets(pkgDir string) (map[string]bool, error) {
+	entries, err := os.ReadDir(pkgDir)
+	if err != nil {
+		return nil, errs.New(errs.CodeUsage, "failed to read package directory", err)
+	}
+	targets := make(map[string]bool)
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(pkgDir, entry.Name()), nil, parser.SkipObjectResolution)
+		if err != nil {
+			continue
+		}
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil {
+				continue
+			}
+			if top, ok := matchTopLevelName(fn.Name.Name); ok && top.kind == ScopeKindFuzz {
+				targets[fn.Name.Name] = true
+			}
+		}
+	}
+	return targets, nil
+}
@@ -0,0 +1,193 @@
+package locator
+
+import (
+	"go/ast"
+	"go/token"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var ginkgoImportPaths = []string{"github.com/onsi/ginkgo/v2", "github.com/onsi/ginkgo"}
+
+var ginkgoContainers = map[string]bool{
+	"Describe": true, "FDescribe": true, "PDescribe": true, "XDescribe": true,
+	"Context": true, "FContext": true, "PContext": true, "XContext": true,
+	"When": true, "FWhen": true, "PWhen": true, "XWhen": true,
+	"DescribeTable": true, "FDescribeTable": true, "PDescribeTable": true, "XDescribeTable": true,
+	"DescribeTableSubtree": true, "FDescribeTableSubtree": true, "PDescribeTableSubtree": true, "XDescribeTableSubtree": true,
+}
+
+var ginkgoSpecs = map[string]bool{
+	"It": true, "FIt": true, "PIt": true, "XIt": true,
+	"Specify": true, "FSpecify": true, "PSpecify": true, "XSpecify": true,
+	"Entry": true, "FEntry": true, "PEntry": true, "XEntry": true,
+}
+
+type ginkgoSuite struct {
+	entries     []string
+	description string
+}
+
+type ginkgoImports struct {
+	aliases map[string]bool
+	dot     bool
+}
+
+func collectGinkgoImports(file *ast.File) ginkgoImports {
+	imports := ginkgoImports{aliases: make(map[string]bool)}
+	for _, path := range ginkgoImportPaths {
+		aliases, dot := collectImportAliases(file, path)
+		for alias := range aliases {
+			imports.aliases[alias] = true
+		}
+		imports.dot = imports.dot || dot
+	}
+	return imports
+}
+
+func (g ginkgoImports) empty() bool {
+	return len(g.aliases) == 0 && !g.dot
+}
+
+func (g ginkgoImports) funcName(fun ast.Expr) string {
+	switch f := fun.(type) {
+	case *ast.SelectorExpr:
+		if ident, ok := f.X.(*ast.Ident); ok && g.aliases[ident.Name] {
+			return f.Sel.Name
+		}
+	case *ast.Ident:
+		if g.dot {
+			return f.Name
+		}
+	}
+	return ""
+}
+
+func findGinkgoSuite(files []*ast.File, ctx *evalContext) ginkgoSuite {
+	var suite ginkgoSuite
+	var descriptions []string
+	resolvable := true
+	for _, file := range files {
+		imports := collectGinkgoImports(file)
+		if imports.empty() {
+			continue
+		}
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil || fn.Body == nil || !hasTopLevelPrefix(fn.Name.Name, "Test") {
+				continue
+			}
+			ast.Inspect(fn.Body, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok || imports.funcName(call.Fun) != "RunSpecs" || len(call.Args) < 2 {
+					return true
+				}
+				suite.entries = append(suite.entries, fn.Name.Name)
+				desc, ok := evalString(call.Args[1], ctx)
+				if !ok {
+					resolvable = false
+				}
+				descriptions = append(descriptions, desc)
+				return true
+			})
+		}
+	}
+	sort.Strings(suite.entries)
+	if resolvable && len(descriptions) == 1 {
+		suite.description = descriptions[0]
+	}
+	return suite
+}
+
+func scanGinkgo(file *ast.File, fset *token.FileSet, eval *evalContext, suite ginkgoSuite) []*Scope {
+	imports := collectGinkgoImports(file)
+	if imports.empty() {
+		return nil
+	}
+	root := &Scope{}
+	var visit func(node ast.Node, parent *Scope)
+	visit = func(node ast.Node, parent *Scope) {
+		ast.Inspect(node, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			name := imports.funcName(call.Fun)
+			kind := ScopeKindContainer
+			switch {
+			case ginkgoContainers[name]:
+			case ginkgoSpecs[name]:
+				kind = ScopeKindSpec
+			default:
+				return true
+			}
+			scope := &Scope{
+				Kind:      kind,
+				StartLine: fset.Position(call.Pos()).Line,
+				EndLine:   fset.Position(call.End()).Line,
+				CallLine:  fset.Position(call.Pos()).Line,
+			}
+			if len(call.Args) > 0 {
+				scope.Name, scope.NameResolvable = evalString(call.Args[0], eval)
+			}
+			if body := lastFuncLit(call.Args); body != nil && kind == ScopeKindSpec && name != "Entry" {
+				scope.StartLine = fset.Position(body.Body.Pos()).Line
+				scope.EndLine = fset.Position(body.Body.End()).Line
+			}
+			if parent != root {
+				scope.Parent = parent
+			} else {
+				scope.Entries = suite.entries
+				scope.Description = suite.description
+			}
+			parent.Children = append(parent.Children, scope)
+			for _, arg := range call.Args {
+				visit(arg, scope)
+			}
+			return false
+		})
+	}
+	for _, decl := range file.Decls {
+		visit(decl, root)
+	}
+	return root.Children
+}
+
+func lastFuncLit(args []ast.Expr) *ast.FuncLit {
+	for i := len(args) - 1; i >= 0; i-- {
+		if lit, ok := args[i].(*ast.FuncLit); ok && lit.Body != nil {
+			return lit
+		}
+	}
+	return nil
+}
+
+func isGinkgoScope(scope *Scope) bool {
+	return scope.Kind == ScopeKindContainer || scope.Kind == ScopeKindSpec
+}
+
+func applyGinkgoPattern(res *Resolution, path []*Scope) {
+	top := path[0]
+	last := path[len(path)-1]
+	res.RunPattern = buildAlternationPattern(top.Entries)
+	res.GinkgoFocusFile = regexp.QuoteMeta(filepath.Base(res.FilePath)) + ":" + strconv.Itoa(last.CallLine)
+	texts := make([]string, 0, len(path))
+	for _, scope := range path {
+		if !scope.NameResolvable {
+			return
+		}
+		texts = append(texts, scope.Name)
+	}
+	prefix := " "
+	if top.Description != "" {
+		prefix = "^" + regexp.QuoteMeta(top.Description) + " "
+	}
+	suffix := "$"
+	if last.Kind == ScopeKindContainer {
+		suffix = "( |$)"
+	}
+	res.GinkgoFocus = prefix + regexp.QuoteMeta(strings.Join(texts, " ")) + suffix
+}
@@ -0,0 +1,75 @@
+package locator
+
+import (
+	"go/ast"
+	"go/types"
+	"sort"
+)
+
+const gocheckPath = "gopkg.in/check.v1"
+
+var gocheckC = paramType{importPath: gocheckPath, name: "C"}
+
+type gocheckSuites struct {
+	types   map[string]bool
+	entries []string
+}
+
+func findCheckSuites(files []*ast.File, info *types.Info) gocheckSuites {
+	suites := gocheckSuites{types: make(map[string]bool)}
+	for _, file := range files {
+		aliases, dot := collectImportAliases(file, gocheckPath)
+		if len(aliases) == 0 && !dot {
+			continue
+		}
+		for _, decl := range file.Decls {
+			fn, isFunc := decl.(*ast.FuncDecl)
+			ast.Inspect(decl, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok || len(call.Args) != 1 {
+					return true
+				}
+				switch {
+				case isPackageFunc(call.Fun, "Suite", aliases, dot):
+					if name := suiteTypeName(call.Args[0], info); name != "" {
+						suites.types[name] = true
+					}
+				case isPackageFunc(call.Fun, "TestingT", aliases, dot):
+					if isFunc && fn.Recv == nil && hasTopLevelPrefix(fn.Name.Name, "Test") {
+						suites.entries = append(suites.entries, fn.Name.Name)
+					}
+				}
+				return true
+			})
+		}
+	}
+	sort.Strings(suites.entries)
+	return suites
+}
+
+func (s *scanner) scanCheckMethod(fn *ast.FuncDecl, suites gocheckSuites) *Scope {
+	typeName, _ := receiverTypeName(fn.Recv)
+	if !suites.types[typeName] || len(suites.entries) == 0 || !hasTopLevelPrefix(fn.Name.Name, "Test") {
+		return nil
+	}
+	if _, ok := s.extractParamName(fn.Type, gocheckC); !ok {
+		return nil
+	}
+	return &Scope{
+		Name:           fn.Name.Name,
+		Kind:           ScopeKindCheckMethod,
+		StartLine:      s.fset.Position(fn.Body.Pos()).Line,
+		EndLine:        s.fset.Position(fn.Body.End()).Line,
+		NameResolvable: true,
+		Entries:        suites.entries,
+		Description:    typeName,
+	}
+}
+
+func checkFilter(methods []*Scope) string {
+	names := make([]string, 0, len(methods))
+	for _, method := range methods {
+		names = append(names, method.Description+"."+method.Name)
+	}
+	return buildAlternationPattern(names)
+}
@@ -0,0 +1,126 @@
+package locator
+
+import (
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/loheagn/gun/internal/project"
+)
+
+type helperLoader struct {
+	fset     *token.FileSet
+	importer types.Importer
+	build    build.Context
+	dir      string
+	root     string
+	module   string
+	resolved bool
+	packages map[string]*helperPackage
+}
+
+type helperPackage struct {
+	pkg      *loadedPackage
+	decls    map[string]*ast.FuncDecl
+	files    map[string]*ast.File
+	contexts map[*ast.File]*evalContext
+}
+
+func newHelperLoader(fset *token.FileSet, dir string, imp types.Importer, ctxt build.Context) *helperLoader {
+	if imp == nil {
+		imp = importer.Default()
+	}
+	return &helperLoader{fset: fset, importer: imp, build: ctxt, dir: dir, packages: make(map[string]*helperPackage)}
+}
+
+func (l *helperLoader) function(importPath string, name string) (*ast.FuncDecl, *evalContext) {
+	if l == nil {
+		return nil, nil
+	}
+	hp := l.load(importPath)
+	if hp == nil {
+		return nil, nil
+	}
+	decl := hp.decls[name]
+	if decl == nil {
+		return nil, nil
+	}
+	file := hp.files[name]
+	ctx := hp.contexts[file]
+	if ctx == nil {
+		ctx = newEvalContext(&loadedPackage{fset: hp.pkg.fset, target: file, files: hp.pkg.files, info: hp.pkg.info, importer: l.importer, build: l.build})
+		ctx.helpers = l
+		hp.contexts[file] = ctx
+	}
+	return decl, ctx
+}
+
+func (l *helperLoader) load(importPath string) *helperPackage {
+	if hp, ok := l.packages[importPath]; ok {
+		return hp
+	}
+	l.packages[importPath] = nil
+	if !l.resolved {
+		l.resolved = true
+		if root, err := project.FindModuleRoot(l.dir); err == nil {
+			if module, err := project.ModulePath(root); err == nil {
+				l.root, l.module = root, module
+			}
+		}
+	}
+	if l.module == "" || !strings.HasPrefix(importPath, l.module+"/") {
+		return nil
+	}
+	dir := filepath.Join(l.root, filepath.FromSlash(strings.TrimPrefix(importPath, l.module+"/")))
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	var files []*ast.File
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		if match, err := l.build.MatchFile(dir, name); err == nil && !match {
+			continue
+		}
+		f, err := parser.ParseFile(l.fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			continue
+		}
+		if len(files) > 0 && f.Name.Name != files[0].Name.Name {
+			continue
+		}
+		files = append(files, f)
+	}
+	if len(files) == 0 {
+		return nil
+	}
+
+	hp := &helperPackage{
+		pkg:      &loadedPackage{fset: l.fset, files: files, info: checkFiles(importPath, l.fset, files, l.importer), importer: l.importer, build: l.build},
+		decls:    make(map[string]*ast.FuncDecl),
+		files:    make(map[string]*ast.File),
+		contexts: make(map[*ast.File]*evalContext),
+	}
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil || fn.Body == nil || !fn.Name.IsExported() {
+				continue
+			}
+			hp.decls[fn.Name.Name] = fn
+			hp.files[fn.Name.Name] = file
+		}
+	}
+	l.packages[importPath] = hp
+	return hp
+}
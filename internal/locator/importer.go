@@ -0,0 +1,99 @@
+package locator
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/build"
+	"go/importer"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+type exportImporter struct {
+	exports  map[string]string
+	gc       types.Importer
+	fallback types.Importer
+}
+
+func newPackageImporter(fset *token.FileSet, dir string, ctxt build.Context) types.Importer {
+	imp := &exportImporter{
+		exports:  listExports(dir, ctxt),
+		fallback: importer.Default(),
+	}
+	imp.gc = importer.ForCompiler(fset, "gc", imp.lookup)
+	return imp
+}
+
+func (i *exportImporter) Import(path string) (*types.Package, error) {
+	if _, ok := i.exports[path]; ok {
+		if pkg, err := i.gc.Import(path); err == nil {
+			return pkg, nil
+		}
+	}
+	return i.fallback.Import(path)
+}
+
+func (i *exportImporter) lookup(path string) (io.ReadCloser, error) {
+	file, ok := i.exports[path]
+	if !ok {
+		return nil, fmt.Errorf("no export data for %q", path)
+	}
+	return os.Open(file)
+}
+
+type sourceImporter struct {
+	pkg  *types.Package
+	next types.Importer
+}
+
+func (i *sourceImporter) Import(path string) (*types.Package, error) {
+	if i.pkg != nil && path == i.pkg.Path() {
+		return i.pkg, nil
+	}
+	return i.next.Import(path)
+}
+
+var exportCache = struct {
+	sync.Mutex
+	dirs map[string]map[string]string
+}{dirs: make(map[string]map[string]string)}
+
+func listExports(dir string, ctxt build.Context) map[string]string {
+	tags := strings.Join(ctxt.BuildTags, ",")
+	key := strings.Join([]string{dir, ctxt.GOOS, ctxt.GOARCH, tags}, "\x00")
+	exportCache.Lock()
+	defer exportCache.Unlock()
+	if exports, ok := exportCache.dirs[key]; ok {
+		return exports
+	}
+	exports := make(map[string]string)
+	exportCache.dirs[key] = exports
+	args := []string{"list", "-e", "-export", "-deps", "-test"}
+	if tags != "" {
+		args = append(args, "-tags", tags)
+	}
+	args = append(args, "-f", "{{if .Export}}{{.ImportPath}}\t{{.Export}}{{end}}", ".")
+	cmd := exec.Command("go", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOOS="+ctxt.GOOS, "GOARCH="+ctxt.GOARCH)
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil && stdout.Len() == 0 {
+		return exports
+	}
+	scanner := bufio.NewScanner(&stdout)
+	for scanner.Scan() {
+		path, file, ok := strings.Cut(scanner.Text(), "\t")
+		if !ok || strings.Contains(path, " [") || strings.HasSuffix(path, ".test") {
+			continue
+		}
+		exports[path] = file
+	}
+	return exports
+}
@@ -0,0 +1,182 @@
+package locator
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+type localVar struct {
+	init     ast.Expr
+	scope    ast.Node
+	appends  []*ast.AssignStmt
+	mutated  bool
+	hasValue bool
+}
+
+func indexLocals(files []*ast.File, info *types.Info) map[types.Object]*localVar {
+	locals := make(map[types.Object]*localVar)
+	lookup := func(expr ast.Expr) *localVar {
+		ident, ok := ast.Unparen(expr).(*ast.Ident)
+		if !ok {
+			return nil
+		}
+		return locals[info.Uses[ident]]
+	}
+	mutate := func(expr ast.Expr) {
+		if local := lookup(expr); local != nil {
+			local.mutated = true
+		}
+	}
+	define := func(ident *ast.Ident, value ast.Expr, scope ast.Node) {
+		obj, ok := info.Defs[ident].(*types.Var)
+		if !ok || obj.Parent() == nil || obj.Parent() == obj.Pkg().Scope() {
+			return
+		}
+		if _, ok := obj.Type().Underlying().(*types.Basic); !ok {
+			return
+		}
+		locals[obj] = &localVar{init: value, scope: scope, hasValue: value != nil}
+	}
+
+	for _, file := range files {
+		var stack []ast.Node
+		ast.Inspect(file, func(n ast.Node) bool {
+			if n == nil {
+				stack = stack[:len(stack)-1]
+				return true
+			}
+			var parent ast.Node
+			if len(stack) > 0 {
+				parent = stack[len(stack)-1]
+			}
+			stack = append(stack, n)
+
+			switch node := n.(type) {
+			case *ast.AssignStmt:
+				switch node.Tok {
+				case token.DEFINE:
+					for i, lhs := range node.Lhs {
+						ident, ok := lhs.(*ast.Ident)
+						if !ok {
+							continue
+						}
+						if _, isNew := info.Defs[ident]; !isNew {
+							mutate(ident)
+							continue
+						}
+						if len(node.Lhs) == len(node.Rhs) {
+							define(ident, node.Rhs[i], parent)
+						}
+					}
+				case token.ADD_ASSIGN:
+					if local := lookup(node.Lhs[0]); local != nil {
+						if parent != local.scope {
+							local.mutated = true
+						} else {
+							local.appends = append(local.appends, node)
+						}
+					}
+				default:
+					for _, lhs := range node.Lhs {
+						mutate(lhs)
+					}
+				}
+			case *ast.DeclStmt:
+				gen, ok := node.Decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.VAR {
+					return true
+				}
+				for _, spec := range gen.Specs {
+					vs := spec.(*ast.ValueSpec)
+					for i, name := range vs.Names {
+						switch {
+						case len(vs.Values) == 0:
+							define(name, nil, parent)
+						case len(vs.Values) == len(vs.Names):
+							define(name, vs.Values[i], parent)
+						}
+					}
+				}
+			case *ast.IncDecStmt:
+				mutate(node.X)
+			case *ast.UnaryExpr:
+				if node.Op == token.AND {
+					mutate(node.X)
+				}
+			case *ast.RangeStmt:
+				if node.Tok == token.ASSIGN {
+					mutate(node.Key)
+					if node.Value != nil {
+						mutate(node.Value)
+					}
+				}
+			}
+			return true
+		})
+	}
+	return locals
+}
+
+func localParts(ident *ast.Ident, ctx *evalContext, kind types.BasicInfo) (*localVar, []ast.Expr, bool) {
+	if ctx == nil || ctx.info == nil {
+		return nil, nil, false
+	}
+	obj := ctx.info.Uses[ident]
+	local := ctx.locals[obj]
+	if local == nil || local.mutated {
+		return nil, nil, false
+	}
+	if basic, ok := obj.Type().Underlying().(*types.Basic); !ok || basic.Info()&kind == 0 {
+		return nil, nil, false
+	}
+	var parts []ast.Expr
+	for _, assign := range local.appends {
+		if assign.Pos() < ident.Pos() {
+			parts = append(parts, assign.Rhs[0])
+		}
+	}
+	return local, parts, true
+}
+
+func localString(ident *ast.Ident, ctx *evalContext) (string, bool) {
+	local, parts, ok := localParts(ident, ctx, types.IsString)
+	if !ok {
+		return "", false
+	}
+	var value string
+	if local.hasValue {
+		if value, ok = evalString(local.init, ctx); !ok {
+			return "", false
+		}
+	}
+	for _, part := range parts {
+		s, ok := evalString(part, ctx)
+		if !ok {
+			return "", false
+		}
+		value += s
+	}
+	return value, true
+}
+
+func localInt(ident *ast.Ident, ctx *evalContext) (int64, bool) {
+	local, parts, ok := localParts(ident, ctx, types.IsInteger)
+	if !ok {
+		return 0, false
+	}
+	var value int64
+	if local.hasValue {
+		if value, ok = evalInt(local.init, ctx); !ok {
+			return 0, false
+		}
+	}
+	for _, part := range parts {
+		i, ok := evalInt(part, ctx)
+		if !ok {
+			return 0, false
+		}
+		value += i
+	}
+	return value, true
+}
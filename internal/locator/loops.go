@@ -0,0 +1,305 @@
+package locator
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+)
+
+const maxLoopIterations = 64
+
+type loopBindings map[types.Object]constant.Value
+
+func indexForLoops(files []*ast.File, info *types.Info) map[types.Object]*ast.ForStmt {
+	loops := make(map[types.Object]*ast.ForStmt)
+	for _, file := range files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			loop, ok := n.(*ast.ForStmt)
+			if !ok {
+				return true
+			}
+			init, ok := loop.Init.(*ast.AssignStmt)
+			if !ok || init.Tok != token.DEFINE || len(init.Lhs) != 1 {
+				return true
+			}
+			if ident, ok := init.Lhs[0].(*ast.Ident); ok {
+				if obj := info.Defs[ident]; obj != nil {
+					loops[obj] = loop
+				}
+			}
+			return true
+		})
+	}
+	return loops
+}
+
+func loopAlternatives(nameExpr ast.Expr, ctx *evalContext) []string {
+	if ctx == nil || ctx.info == nil {
+		return nil
+	}
+	var loops []ast.Stmt
+	seen := make(map[ast.Stmt]bool)
+	ast.Inspect(nameExpr, func(n ast.Node) bool {
+		ident, ok := n.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		obj := ctx.info.Uses[ident]
+		var loop ast.Stmt
+		if rng := ctx.rangeStmts[obj]; rng != nil {
+			loop = rng
+		} else if f := ctx.forStmts[obj]; f != nil {
+			loop = f
+		}
+		if loop != nil && !seen[loop] {
+			seen[loop] = true
+			loops = append(loops, loop)
+		}
+		return true
+	})
+	if len(loops) == 0 {
+		return nil
+	}
+	sort.Slice(loops, func(i, j int) bool { return loops[i].Pos() < loops[j].Pos() })
+
+	combos := []loopBindings{{}}
+	for _, loop := range loops {
+		iterations, ok := loopIterations(loop, ctx)
+		if !ok || len(combos)*len(iterations) > maxLoopIterations {
+			return nil
+		}
+		next := make([]loopBindings, 0, len(combos)*len(iterations))
+		for _, combo := range combos {
+			for _, iteration := range iterations {
+				merged := make(loopBindings, len(combo)+len(iteration))
+				for obj, v := range combo {
+					merged[obj] = v
+				}
+				for obj, v := range iteration {
+					merged[obj] = v
+				}
+				next = append(next, merged)
+			}
+		}
+		combos = next
+	}
+
+	names := make([]string, 0, len(combos))
+	for _, combo := range combos {
+		inner := *ctx
+		inner.bindings = make(map[types.Object]constant.Value, len(ctx.bindings)+len(combo))
+		for obj, v := range ctx.bindings {
+			inner.bindings[obj] = v
+		}
+		for obj, v := range combo {
+			inner.bindings[obj] = v
+		}
+		name, ok := evalString(nameExpr, &inner)
+		if !ok || strings.Contains(name, "/") {
+			return nil
+		}
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	return names
+}
+
+func loopIterations(loop ast.Stmt, ctx *evalContext) ([]loopBindings, bool) {
+	switch l := loop.(type) {
+	case *ast.RangeStmt:
+		return rangeIterations(l, ctx)
+	case *ast.ForStmt:
+		return forIterations(l, ctx)
+	}
+	return nil, false
+}
+
+func rangeIterations(rng *ast.RangeStmt, ctx *evalContext) ([]loopBindings, bool) {
+	key := rangeVarObject(rng.Key, ctx)
+	value := rangeVarObject(rng.Value, ctx)
+	if mutatesAny(rng.Body, ctx, key, value) {
+		return nil, false
+	}
+	if tv, ok := ctx.info.Types[rng.X]; ok && tv.Type != nil {
+		if basic, ok := tv.Type.Underlying().(*types.Basic); ok && basic.Info()&types.IsInteger != 0 {
+			n, ok := evalInt(rng.X, ctx)
+			if !ok || n > maxLoopIterations {
+				return nil, false
+			}
+			iterations := make([]loopBindings, 0, n)
+			for i := int64(0); i < n; i++ {
+				iterations = append(iterations, bindLoopVars(key, constant.MakeInt64(i), nil, nil))
+			}
+			return iterations, true
+		}
+	}
+	lit := compositeLitOf(rng.X, ctx, 0)
+	if lit == nil || !isSliceLit(lit, ctx) || len(lit.Elts) > maxLoopIterations {
+		return nil, false
+	}
+	iterations := make([]loopBindings, 0, len(lit.Elts))
+	for i, elt := range lit.Elts {
+		if _, keyed := elt.(*ast.KeyValueExpr); keyed {
+			return nil, false
+		}
+		var v constant.Value
+		if value != nil {
+			var ok bool
+			if v, ok = elementValue(elt, ctx); !ok {
+				return nil, false
+			}
+		}
+		iterations = append(iterations, bindLoopVars(key, constant.MakeInt64(int64(i)), value, v))
+	}
+	return iterations, true
+}
+
+func forIterations(loop *ast.ForStmt, ctx *evalContext) ([]loopBindings, bool) {
+	init := loop.Init.(*ast.AssignStmt)
+	obj := ctx.info.Defs[init.Lhs[0].(*ast.Ident)]
+	if len(init.Rhs) != 1 || mutatesAny(loop.Body, ctx, obj) {
+		return nil, false
+	}
+	start, ok := evalInt(init.Rhs[0], ctx)
+	if !ok {
+		return nil, false
+	}
+	cond, ok := loop.Cond.(*ast.BinaryExpr)
+	if !ok || !isObjectIdent(cond.X, obj, ctx) {
+		return nil, false
+	}
+	bound, ok := evalInt(cond.Y, ctx)
+	if !ok {
+		return nil, false
+	}
+	step, ok := loopStep(loop.Post, obj, ctx)
+	if !ok || step == 0 {
+		return nil, false
+	}
+	var iterations []loopBindings
+	for i := start; ; i += step {
+		var holds bool
+		switch cond.Op {
+		case token.LSS:
+			holds = i < bound
+		case token.LEQ:
+			holds = i <= bound
+		case token.GTR:
+			holds = i > bound
+		case token.GEQ:
+			holds = i >= bound
+		case token.NEQ:
+			holds = i != bound
+		default:
+			return nil, false
+		}
+		if !holds {
+			return iterations, true
+		}
+		if len(iterations) == maxLoopIterations {
+			return nil, false
+		}
+		iterations = append(iterations, bindLoopVars(obj, constant.MakeInt64(i), nil, nil))
+	}
+}
+
+func loopStep(post ast.Stmt, obj types.Object, ctx *evalContext) (int64, bool) {
+	switch p := post.(type) {
+	case *ast.IncDecStmt:
+		if !isObjectIdent(p.X, obj, ctx) {
+			return 0, false
+		}
+		if p.Tok == token.INC {
+			return 1, true
+		}
+		return -1, true
+	case *ast.AssignStmt:
+		if len(p.Lhs) != 1 || len(p.Rhs) != 1 || !isObjectIdent(p.Lhs[0], obj, ctx) {
+			return 0, false
+		}
+		step, ok := evalInt(p.Rhs[0], ctx)
+		if !ok {
+			return 0, false
+		}
+		switch p.Tok {
+		case token.ADD_ASSIGN:
+			return step, true
+		case token.SUB_ASSIGN:
+			return -step, true
+		}
+	}
+	return 0, false
+}
+
+func elementValue(expr ast.Expr, ctx *evalContext) (constant.Value, bool) {
+	if v, ok := constValue(expr, ctx); ok {
+		return v, true
+	}
+	if s, ok := evalString(expr, ctx); ok {
+		return constant.MakeString(s), true
+	}
+	if i, ok := evalInt(expr, ctx); ok {
+		return constant.MakeInt64(i), true
+	}
+	return nil, false
+}
+
+func rangeVarObject(expr ast.Expr, ctx *evalContext) types.Object {
+	ident, ok := expr.(*ast.Ident)
+	if !ok || ident.Name == "_" {
+		return nil
+	}
+	return ctx.info.Defs[ident]
+}
+
+func bindLoopVars(key types.Object, k constant.Value, value types.Object, v constant.Value) loopBindings {
+	bindings := make(loopBindings, 2)
+	if key != nil {
+		bindings[key] = k
+	}
+	if value != nil {
+		bindings[value] = v
+	}
+	return bindings
+}
+
+func isObjectIdent(expr ast.Expr, obj types.Object, ctx *evalContext) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && obj != nil && ctx.info.Uses[ident] == obj
+}
+
+func mutatesAny(body *ast.BlockStmt, ctx *evalContext, objs ...types.Object) bool {
+	targets := make(map[types.Object]bool, len(objs))
+	for _, obj := range objs {
+		if obj != nil {
+			targets[obj] = true
+		}
+	}
+	mutated := false
+	touches := func(expr ast.Expr) {
+		if ident, ok := ast.Unparen(expr).(*ast.Ident); ok && targets[ctx.info.Uses[ident]] {
+			mutated = true
+		}
+	}
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range node.Lhs {
+				touches(lhs)
+			}
+		case *ast.IncDecStmt:
+			touches(node.X)
+		case *ast.UnaryExpr:
+			if node.Op == token.AND {
+				touches(node.X)
+			}
+		}
+		return !mutated
+	})
+	return mutated
+}
@@ -3,8 +3,16 @@ package locator
 type ScopeKind string
 
 const (
-	ScopeKindTest    ScopeKind = "test"
-	ScopeKindSubtest ScopeKind = "subtest"
+	ScopeKindTest        ScopeKind = "test"
+	ScopeKindBenchmark   ScopeKind = "benchmark"
+	ScopeKindFuzz        ScopeKind = "fuzz"
+	ScopeKindExample     ScopeKind = "example"
+	ScopeKindSuiteMethod ScopeKind = "suite_method"
+	ScopeKindContainer   ScopeKind = "container"
+	ScopeKindSpec        ScopeKind = "spec"
+	ScopeKindSubtest     ScopeKind = "subtest"
+	ScopeKindSeed        ScopeKind = "seed"
+	ScopeKindCheckMethod ScopeKind = "check_method"
 )
 
 type Scope struct {
@@ -13,6 +21,14 @@ type Scope struct {
 	StartLine      int
 	EndLine        int
 	NameResolvable bool
+	NoOutput       bool
+	Entries        []string
+	Alternatives   []string
+	Description    string
+	CallLine       int
+	File           string
+	Callee         string
+	CallSite       *Scope
 	Children       []*Scope
 	Parent         *Scope
 }
@@ -26,19 +42,36 @@ const (
 	ModeFile    Mode = "file"
 	ModePkg     Mode = "pkg"
 	ModeProject Mode = "project"
+	ModeRdeps   Mode = "rdeps"
+	ModeFuzz    Mode = "fuzz"
 	ModeAuto    Mode = "auto"
 )
 
 type ResolveOptions struct {
 	ParentUp    int
 	ProjectRoot string
+	BuildTags   []string
+	GOOS        string
+	GOARCH      string
+	Depth       int
 }
 
 type Resolution struct {
-	Mode       Mode
-	Effective  Mode
-	PackageDir string
-	ModuleRoot string
-	FilePath   string
-	RunPattern string
+	Mode            Mode
+	Effective       Mode
+	PackageDir      string
+	ModuleRoot      string
+	FilePath        string
+	RunPattern      string
+	BenchPattern    string
+	FuzzPattern     string
+	TestifyPattern  string
+	GinkgoFocus     string
+	GinkgoFocusFile string
+	CheckFilter     string
+	GOOS            string
+	GOARCH          string
+	BuildTags       []string
+	Packages        []string
+	Notes           []string
 }
@@ -0,0 +1,138 @@
+package locator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/loheagn/gun/internal/errs"
+	"github.com/loheagn/gun/internal/project"
+)
+
+type listedPackage struct {
+	ImportPath   string
+	Dir          string
+	ForTest      string
+	DepOnly      bool
+	Imports      []string
+	TestImports  []string
+	XTestImports []string
+	TestGoFiles  []string
+	XTestGoFiles []string
+}
+
+func resolveReverseDeps(res Resolution, pkgDir string, opts ResolveOptions) (Resolution, error) {
+	root, err := project.ResolveRoot(res.FilePath, opts.ProjectRoot)
+	if err != nil {
+		return Resolution{}, err
+	}
+	packages, err := listPackages(root, opts)
+	if err != nil {
+		return Resolution{}, err
+	}
+	absDir, err := filepath.Abs(pkgDir)
+	if err != nil {
+		return Resolution{}, errs.New(errs.CodeUsage, "failed to resolve package directory", err)
+	}
+	target := ""
+	for _, pkg := range packages {
+		if pkg.ForTest == "" && filepath.Clean(pkg.Dir) == filepath.Clean(absDir) {
+			target = pkg.ImportPath
+			break
+		}
+	}
+	if target == "" {
+		return Resolution{}, errs.New(errs.CodeUsage, fmt.Sprintf("no package in %q under module root %q", absDir, root), nil)
+	}
+
+	res.ModuleRoot = root
+	res.Packages = reverseDeps(packages, target, opts.Depth)
+	if len(res.Packages) == 0 {
+		return Resolution{}, errs.New(errs.CodeUsage, fmt.Sprintf("no package with tests imports %s", target), nil)
+	}
+	note := fmt.Sprintf("running tests of %d packages that import %s", len(res.Packages), target)
+	if opts.Depth > 0 {
+		note += fmt.Sprintf(" within depth %d", opts.Depth)
+	}
+	res.Notes = append(res.Notes, note)
+	return res, nil
+}
+
+func reverseDeps(packages []listedPackage, target string, depth int) []string {
+	importers := make(map[string][]string)
+	for _, pkg := range packages {
+		if pkg.ForTest != "" {
+			continue
+		}
+		for _, imp := range pkg.Imports {
+			importers[imp] = append(importers[imp], pkg.ImportPath)
+		}
+	}
+	dist := map[string]int{target: 0}
+	queue := []string{target}
+	for len(queue) > 0 {
+		path := queue[0]
+		queue = queue[1:]
+		for _, importer := range importers[path] {
+			if _, seen := dist[importer]; !seen {
+				dist[importer] = dist[path] + 1
+				queue = append(queue, importer)
+			}
+		}
+	}
+
+	var out []string
+	for _, pkg := range packages {
+		if pkg.ForTest != "" || pkg.DepOnly || len(pkg.TestGoFiles)+len(pkg.XTestGoFiles) == 0 {
+			continue
+		}
+		d, ok := dist[pkg.ImportPath]
+		for _, imp := range append(pkg.TestImports, pkg.XTestImports...) {
+			if through, reached := dist[imp]; reached && (!ok || through+1 < d) {
+				d, ok = through+1, true
+			}
+		}
+		if ok && (depth <= 0 || d <= depth) {
+			out = append(out, pkg.ImportPath)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func listPackages(root string, opts ResolveOptions) ([]listedPackage, error) {
+	ctxt := buildContext(opts)
+	args := []string{"list", "-e", "-deps", "-test", "-json"}
+	if len(ctxt.BuildTags) > 0 {
+		args = append(args, "-tags", strings.Join(ctxt.BuildTags, ","))
+	}
+	args = append(args, "./...")
+	cmd := exec.Command("go", args...)
+	cmd.Dir = root
+	cmd.Env = append(os.Environ(), "GOOS="+ctxt.GOOS, "GOARCH="+ctxt.GOARCH)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil && stdout.Len() == 0 {
+		return nil, errs.New(errs.CodeUsage, fmt.Sprintf("go list failed: %s", strings.TrimSpace(stderr.String())), err)
+	}
+	var packages []listedPackage
+	dec := json.NewDecoder(&stdout)
+	for {
+		var pkg listedPackage
+		if err := dec.Decode(&pkg); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, errs.New(errs.CodeUsage, "failed to decode go list output", err)
+		}
+		packages = append(packages, pkg)
+	}
+	return packages, nil
+}
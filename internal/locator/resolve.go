@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
 
+	"github.com/loheagn/gun/internal/config"
 	"github.com/loheagn/gun/internal/errs"
 	"github.com/loheagn/gun/internal/project"
 )
@@ -17,6 +19,13 @@ func Resolve(mode Mode, filePath string, line int, opts ResolveOptions) (Resolut
 		Effective:  mode,
 		FilePath:   filePath,
 		PackageDir: filepath.Dir(filePath),
+		GOOS:       opts.GOOS,
+		GOARCH:     opts.GOARCH,
+	}
+	if tags, note := inferFileTags(filePath, opts); len(tags) > 0 {
+		opts.BuildTags = append(slices.Clone(opts.BuildTags), tags...)
+		res.BuildTags = tags
+		res.Notes = append(res.Notes, note)
 	}
 
 	switch mode {
@@ -29,66 +38,146 @@ func Resolve(mode Mode, filePath string, line int, opts ResolveOptions) (Resolut
 		}
 		res.ModuleRoot = root
 		return res, nil
+	case ModeRdeps:
+		return resolveReverseDeps(res, filepath.Dir(filePath), opts)
+	}
+	if !strings.HasSuffix(filePath, "_test.go") {
+		return resolveSource(res, mode, filePath, line, opts)
 	}
 
-	fset, target, info, err := loadPackageTypes(filePath)
+	cfg, err := config.Load(filepath.Dir(filePath))
 	if err != nil {
 		return Resolution{}, err
 	}
-	fmtAliases, fmtDot := collectImportAliases(target, "fmt")
-	strconvAliases, strconvDot := collectImportAliases(target, "strconv")
-	ctx := &evalContext{
-		info:           info,
-		fmtAliases:     fmtAliases,
-		fmtDot:         fmtDot,
-		strconvAliases: strconvAliases,
-		strconvDot:     strconvDot,
+	pkg, err := loadPackageTypes(filePath, opts)
+	if err != nil {
+		return Resolution{}, err
 	}
-	scan := scanTests(target, fset, ctx)
-	if len(scan.Tests) == 0 {
-		return Resolution{}, errs.New(errs.CodeUsage, "no top-level TestXxx found in file", nil)
+	ctx := newEvalContext(pkg)
+	suites := findPackageSuites(pkg.files, pkg.info)
+	targetFile := pkg.fset.Position(pkg.target.Pos()).Filename
+	scan := scanTests(pkg.target, pkg.fset, ctx, suites, cfg)
+	scan.Tests = append(scan.Tests, scanGinkgo(pkg.target, pkg.fset, ctx, findGinkgoSuite(pkg.files, ctx))...)
+	scopes := scan.Tests
+	for _, file := range pkg.files {
+		if file != pkg.target {
+			scopes = append(scopes, scanTests(file, pkg.fset, ctx, suites, cfg).Tests...)
+		}
+	}
+	paths := innermostPaths(scopes, targetFile, line)
+	if len(scan.Tests) == 0 && (mode == ModeFile || len(paths) == 0) {
+		return Resolution{}, errs.New(errs.CodeUsage, "no top-level TestXxx, BenchmarkXxx, FuzzXxx, ExampleXxx, suite method or Ginkgo spec found in file", nil)
 	}
 
 	switch mode {
 	case ModeFile:
-		res.RunPattern = buildFilePattern(scan.Tests)
+		names := pathNames(scopesOfKind(scan.Tests, ScopeKindTest, ScopeKindFuzz, ScopeKindExample))
+		if methods := scopesOfKind(scan.Tests, ScopeKindSuiteMethod); len(methods) > 0 {
+			for _, method := range methods {
+				names = append(names, method.Entries...)
+			}
+			res.TestifyPattern = buildFilePattern(methods)
+		}
+		if specs := scopesOfKind(scan.Tests, ScopeKindContainer, ScopeKindSpec); len(specs) > 0 {
+			names = append(names, specs[0].Entries...)
+			res.GinkgoFocusFile = regexp.QuoteMeta(filepath.Base(filePath))
+		}
+		if methods := scopesOfKind(scan.Tests, ScopeKindCheckMethod); len(methods) > 0 {
+			names = append(names, methods[0].Entries...)
+			res.CheckFilter = checkFilter(methods)
+		}
+		res.RunPattern = buildAlternationPattern(names)
+		res.BenchPattern = buildFilePattern(scopesOfKind(scan.Tests, ScopeKindBenchmark))
+		for _, example := range scopesOfKind(scan.Tests, ScopeKindExample) {
+			if example.NoOutput {
+				res.Notes = append(res.Notes, noOutputMessage(example))
+			}
+		}
 		return res, nil
 	case ModeLeaf, ModeParent, ModeTest, ModeAuto:
-		top := findContainingTop(scan.Tests, line)
-		if top == nil {
+		if len(paths) == 0 {
 			return Resolution{}, errs.New(errs.CodeUsage, fmt.Sprintf("line %d is not inside any Test/t.Run block; try test/file/pkg/project", line), nil)
 		}
-		path := deepestPath(top, line, nil)
-		return resolveFromPath(res, mode, path, opts.ParentUp)
+		if paths[0][0].NoOutput {
+			return Resolution{}, errs.New(errs.CodeUsage, noOutputMessage(paths[0][0]), nil)
+		}
+		if len(paths) == 1 {
+			return resolveFromPath(res, mode, paths[0], opts.ParentUp)
+		}
+		return resolveFromPaths(res, mode, paths, opts.ParentUp)
+	case ModeFuzz:
+		path := innermostPath(scan.Tests, targetFile, line)
+		if len(path) == 0 || path[0].Kind != ScopeKindFuzz {
+			return Resolution{}, errs.New(errs.CodeUsage, fmt.Sprintf("line %d is not inside any FuzzXxx target", line), nil)
+		}
+		res.RunPattern = buildSegmentPattern([]string{path[0].Name})
+		res.FuzzPattern = res.RunPattern
+		return res, nil
 	default:
 		return Resolution{}, errs.New(errs.CodeUsage, fmt.Sprintf("unsupported mode %q", mode), nil)
 	}
 }
 
 func resolveFromPath(res Resolution, mode Mode, path []*Scope, parentUp int) (Resolution, error) {
+	selected, effective, err := selectPath(mode, path, parentUp)
+	if err != nil {
+		return Resolution{}, err
+	}
+	res.Effective = effective
+	applyPattern(&res, selected)
+	return res, nil
+}
+
+func resolveFromPaths(res Resolution, mode Mode, paths [][]*Scope, parentUp int) (Resolution, error) {
+	kind := paths[0][0].Kind
+	if kind != ScopeKindTest && kind != ScopeKindBenchmark {
+		return resolveFromPath(res, mode, paths[0], parentUp)
+	}
+	var selectedPaths [][]*Scope
+	res.Effective = ""
+	for _, path := range paths {
+		selected, effective, err := selectPath(mode, path, parentUp)
+		if err != nil {
+			return Resolution{}, err
+		}
+		if path[0].Kind != kind {
+			return resolveFromPath(res, mode, paths[0], parentUp)
+		}
+		if res.Effective == "" || effective == ModeTest {
+			res.Effective = effective
+		}
+		selectedPaths = append(selectedPaths, selected)
+	}
+	pattern := buildPathsPattern(selectedPaths)
+	if kind == ScopeKindBenchmark {
+		res.BenchPattern = pattern
+	} else {
+		res.RunPattern = pattern
+	}
+	res.Notes = append(res.Notes, fmt.Sprintf("line is inside %s, which %d t.Run calls use; running all of them", calleeOf(paths[0]), len(paths)))
+	return res, nil
+}
+
+func selectPath(mode Mode, path []*Scope, parentUp int) ([]*Scope, Mode, error) {
 	if len(path) == 0 {
-		return Resolution{}, errs.New(errs.CodeUsage, "internal error: empty test path", nil)
+		return nil, "", errs.New(errs.CodeUsage, "internal error: empty test path", nil)
 	}
 	top := path[0]
-	if top.Name == "" {
-		return Resolution{}, errs.New(errs.CodeUsage, "internal error: top test has empty name", nil)
+	if top.Name == "" && !isGinkgoScope(top) {
+		return nil, "", errs.New(errs.CodeUsage, "internal error: top test has empty name", nil)
 	}
 
 	switch mode {
 	case ModeTest:
-		res.RunPattern = buildSegmentPattern([]string{top.Name})
-		return res, nil
+		return path[:1], mode, nil
 	case ModeLeaf:
 		if len(path) == 1 {
-			res.Effective = ModeTest
-			res.RunPattern = buildSegmentPattern([]string{top.Name})
-			return res, nil
+			return path[:1], ModeTest, nil
 		}
 		if !allSubtestsResolvable(path) {
-			return Resolution{}, errs.New(errs.CodeUsage, "unable to resolve subtest name for leaf; use test/file/pkg/project", nil)
+			return nil, "", errs.New(errs.CodeUsage, "unable to resolve subtest name for leaf; use test/file/pkg/project", nil)
 		}
-		res.RunPattern = buildSegmentPattern(pathNames(path))
-		return res, nil
+		return path, mode, nil
 	case ModeParent:
 		if parentUp <= 0 {
 			parentUp = 1
@@ -99,51 +188,111 @@ func resolveFromPath(res Resolution, mode Mode, path []*Scope, parentUp int) (Re
 		}
 		targetPath := path[:selected+1]
 		if selected == 0 {
-			res.Effective = ModeTest
-			res.RunPattern = buildSegmentPattern([]string{top.Name})
-			return res, nil
+			return path[:1], ModeTest, nil
 		}
 		if !allSubtestsResolvable(targetPath) {
-			return Resolution{}, errs.New(errs.CodeUsage, "unable to resolve subtest name for parent; use test/file/pkg/project", nil)
+			return nil, "", errs.New(errs.CodeUsage, "unable to resolve subtest name for parent; use test/file/pkg/project", nil)
 		}
-		res.RunPattern = buildSegmentPattern(pathNames(targetPath))
-		return res, nil
+		return targetPath, mode, nil
 	case ModeAuto:
 		if len(path) == 1 {
-			res.Effective = ModeTest
-			res.RunPattern = buildSegmentPattern([]string{top.Name})
-			return res, nil
+			return path[:1], ModeTest, nil
 		}
 		if allSubtestsResolvable(path) {
-			res.Effective = ModeLeaf
-			res.RunPattern = buildSegmentPattern(pathNames(path))
-			return res, nil
+			return path, ModeLeaf, nil
 		}
-		res.Effective = ModeTest
-		res.RunPattern = buildSegmentPattern([]string{top.Name})
-		return res, nil
+		return path[:1], ModeTest, nil
+	default:
+		return nil, "", errs.New(errs.CodeUsage, fmt.Sprintf("unsupported path mode %q", mode), nil)
+	}
+}
+
+func applyPattern(res *Resolution, path []*Scope) {
+	switch path[0].Kind {
+	case ScopeKindBenchmark:
+		res.BenchPattern = buildPathsPattern([][]*Scope{path})
+	case ScopeKindSuiteMethod:
+		res.TestifyPattern = buildSegmentPattern([]string{path[0].Name})
+		entry := buildAlternationPattern(path[0].Entries)
+		if len(path) == 1 {
+			res.RunPattern = entry
+			return
+		}
+		res.RunPattern = entry + "/" + buildPathPattern(path)
+	case ScopeKindCheckMethod:
+		res.RunPattern = buildAlternationPattern(path[0].Entries)
+		res.CheckFilter = checkFilter(path[:1])
+	case ScopeKindContainer, ScopeKindSpec:
+		applyGinkgoPattern(res, path)
 	default:
-		return Resolution{}, errs.New(errs.CodeUsage, fmt.Sprintf("unsupported path mode %q", mode), nil)
+		res.RunPattern = buildPathsPattern([][]*Scope{path})
+	}
+}
+
+func innermostPath(tests []*Scope, file string, line int) []*Scope {
+	paths := innermostPaths(tests, file, line)
+	if len(paths) == 0 {
+		return nil
 	}
+	return paths[len(paths)-1]
 }
 
-func findContainingTop(tests []*Scope, line int) *Scope {
+func innermostPaths(tests []*Scope, file string, line int) [][]*Scope {
+	var best []*Scope
+	var visit func(scope *Scope)
+	visit = func(scope *Scope) {
+		if containsLine(scope, line) && inFile(scope, file) {
+			switch {
+			case len(best) == 0 || span(scope) < span(best[0]):
+				best = []*Scope{scope}
+			case span(scope) == span(best[0]) && sameHelperBody(scope, best[0]):
+				best = append(best, scope)
+			case span(scope) == span(best[0]):
+				best = []*Scope{scope}
+			}
+		}
+		for _, child := range scope.Children {
+			visit(child)
+		}
+	}
 	for _, test := range tests {
-		if containsLine(test, line) {
-			return test
+		visit(test)
+	}
+	paths := make([][]*Scope, 0, len(best))
+	for _, scope := range best {
+		var path []*Scope
+		for cur := scope; cur != nil; cur = cur.Parent {
+			path = append([]*Scope{cur}, path...)
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+func inFile(scope *Scope, file string) bool {
+	for cur := scope; cur != nil; cur = cur.Parent {
+		if cur.File != "" {
+			return cur.File == file
 		}
 	}
-	return nil
+	return true
+}
+
+func sameHelperBody(a, b *Scope) bool {
+	return a.StartLine == b.StartLine && a.EndLine == b.EndLine && calleeOf([]*Scope{a}) != "" && calleeOf([]*Scope{b}) != ""
 }
 
-func deepestPath(node *Scope, line int, path []*Scope) []*Scope {
-	path = append(path, node)
-	for _, child := range node.Children {
-		if containsLine(child, line) {
-			return deepestPath(child, line, path)
+func calleeOf(path []*Scope) string {
+	for cur := path[len(path)-1]; cur != nil; cur = cur.Parent {
+		if cur.Callee != "" {
+			return cur.Callee
 		}
 	}
-	return path
+	return ""
+}
+
+func span(scope *Scope) int {
+	return scope.EndLine - scope.StartLine
 }
 
 func containsLine(scope *Scope, line int) bool {
@@ -151,8 +300,11 @@ func containsLine(scope *Scope, line int) bool {
 }
 
 func allSubtestsResolvable(path []*Scope) bool {
+	if isGinkgoScope(path[0]) {
+		return true
+	}
 	for i := 1; i < len(path); i++ {
-		if !path[i].NameResolvable || path[i].Name == "" {
+		if !path[i].NameResolvable && len(path[i].Alternatives) == 0 {
 			return false
 		}
 	}
@@ -167,22 +319,128 @@ func pathNames(path []*Scope) []string {
 	return names
 }
 
+func buildPathPattern(path []*Scope) string {
+	return buildLevelsPattern(pathLevels(path))
+}
+
+func pathLevels(path []*Scope) [][]string {
+	levels := make([][]string, 0, len(path))
+	for _, scope := range path {
+		names := []string{scope.Name}
+		if scope.Kind == ScopeKindSubtest {
+			names = subtestRunNames(scope)
+		}
+		if len(names) > 1 {
+			levels = append(levels, names)
+			continue
+		}
+		for _, level := range strings.Split(names[0], "/") {
+			levels = append(levels, []string{level})
+		}
+	}
+	return levels
+}
+
+func buildLevelsPattern(levels [][]string) string {
+	segments := make([]string, 0, len(levels))
+	for _, names := range levels {
+		segments = append(segments, buildAlternationPattern(names))
+	}
+	return strings.Join(segments, "/")
+}
+
+func buildPathsPattern(paths [][]*Scope) string {
+	paths = append(paths, callSitePaths(paths)...)
+	whole := make(map[string]bool)
+	for _, path := range paths {
+		if len(path) == 1 {
+			whole[path[0].Name] = true
+		}
+	}
+	if len(whole) == len(topNames(paths)) {
+		return buildAlternationPattern(topNames(paths))
+	}
+	var patterns []string
+	for _, path := range paths {
+		if len(path) > 1 && whole[path[0].Name] {
+			continue
+		}
+		patterns = append(patterns, buildPathPattern(path))
+	}
+	sort.Strings(patterns)
+	return strings.Join(slices.Compact(patterns), "|")
+}
+
+func callSitePaths(paths [][]*Scope) [][]*Scope {
+	var sites [][]*Scope
+	for _, path := range paths {
+		for _, scope := range path {
+			if scope.CallSite == nil {
+				continue
+			}
+			var site []*Scope
+			for cur := scope.CallSite; cur != nil; cur = cur.Parent {
+				site = append([]*Scope{cur}, site...)
+			}
+			sites = append(sites, site)
+		}
+	}
+	return sites
+}
+
+func topNames(paths [][]*Scope) []string {
+	names := make([]string, 0, len(paths))
+	for _, path := range paths {
+		names = append(names, path[0].Name)
+	}
+	sort.Strings(names)
+	return slices.Compact(names)
+}
+
 func buildSegmentPattern(names []string) string {
 	segments := make([]string, 0, len(names))
 	for _, name := range names {
-		segments = append(segments, "^"+regexp.QuoteMeta(name)+"$")
+		for _, level := range strings.Split(name, "/") {
+			segments = append(segments, "^"+regexp.QuoteMeta(level)+"$")
+		}
 	}
 	return strings.Join(segments, "/")
 }
 
+func noOutputMessage(example *Scope) string {
+	return fmt.Sprintf("%s has no // Output: comment; go test compiles it but never runs it", example.Name)
+}
+
+func scopesOfKind(scopes []*Scope, kinds ...ScopeKind) []*Scope {
+	var out []*Scope
+	for _, scope := range scopes {
+		if slices.Contains(kinds, scope.Kind) {
+			out = append(out, scope)
+		}
+	}
+	return out
+}
+
 func buildFilePattern(tests []*Scope) string {
 	names := make([]string, 0, len(tests))
 	for _, test := range tests {
-		names = append(names, regexp.QuoteMeta(test.Name))
+		names = append(names, test.Name)
 	}
-	sort.Strings(names)
-	if len(names) == 1 {
-		return "^" + names[0] + "$"
+	return buildAlternationPattern(names)
+}
+
+func buildAlternationPattern(names []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	quoted := make([]string, 0, len(names))
+	for _, name := range names {
+		quoted = append(quoted, regexp.QuoteMeta(name))
+	}
+	sort.Strings(quoted)
+	quoted = slices.Compact(quoted)
+	if len(quoted) == 1 {
+		return "^" + quoted[0] + "$"
 	}
-	return "^(" + strings.Join(names, "|") + ")$"
+	return "^(" + strings.Join(quoted, "|") + ")$"
 }
@@ -1,6 +1,8 @@
 package locator
 
 import (
+	"go/build/constraint"
+	"slices"
 	"strings"
 	"testing"
 
@@ -123,6 +125,36 @@ func TestResolveConstAndFormatNames(t *testing.T) {
 	}
 }
 
+func TestResolveLocalVariableNames(t *testing.T) {
+	file := testutil.FixtureFile(t)
+
+	cases := []struct {
+		marker string
+		want   string
+	}{
+		{marker: "local_var", want: "^TestAlpha$/^outer$/^local$"},
+		{marker: "plus_assign", want: "^TestConst$/^chain-const-sub$"},
+		{marker: "local_int", want: "^TestConst$/^id-3$"},
+	}
+	for _, tc := range cases {
+		res, err := Resolve(ModeLeaf, file, testutil.MarkerLine(t, file, tc.marker), ResolveOptions{})
+		if err != nil {
+			t.Fatalf("Resolve %s: %v", tc.marker, err)
+		}
+		if res.RunPattern != tc.want {
+			t.Fatalf("%s: run pattern = %q, want %q", tc.marker, res.RunPattern, tc.want)
+		}
+	}
+
+	res, err := Resolve(ModeAuto, file, testutil.MarkerLine(t, file, "reassigned"), ResolveOptions{})
+	if err != nil {
+		t.Fatalf("Resolve reassigned: %v", err)
+	}
+	if res.Effective != ModeTest || res.RunPattern != "^TestConst$" {
+		t.Fatalf("reassigned: effective = %q, run pattern = %q", res.Effective, res.RunPattern)
+	}
+}
+
 func TestResolveFilePatternAndOutsideError(t *testing.T) {
 	file := testutil.FixtureFile(t)
 	res, err := Resolve(ModeFile, file, 1, ResolveOptions{})
@@ -139,3 +171,745 @@ func TestResolveFilePatternAndOutsideError(t *testing.T) {
 		t.Fatalf("expected outside line error")
 	}
 }
+
+func TestResolveTableCases(t *testing.T) {
+	file := testutil.FixturePath(t, "sample", "table_test.go")
+
+	cases := []struct {
+		marker string
+		want   string
+	}{
+		{marker: "table_keyed", want: "^TestTable$/^second$"},
+		{marker: "table_positional", want: "^TestTable$/^third$"},
+		{marker: "table_inline", want: "^TestInlineTable$/^only$"},
+	}
+	for _, tc := range cases {
+		res, err := Resolve(ModeAuto, file, testutil.MarkerLine(t, file, tc.marker), ResolveOptions{})
+		if err != nil {
+			t.Fatalf("Resolve %s: %v", tc.marker, err)
+		}
+		if res.Effective != ModeLeaf || res.RunPattern != tc.want {
+			t.Fatalf("%s: effective = %q, run pattern = %q, want %q", tc.marker, res.Effective, res.RunPattern, tc.want)
+		}
+	}
+
+	res, err := Resolve(ModeAuto, file, testutil.MarkerLine(t, file, "table_body"), ResolveOptions{})
+	if err != nil {
+		t.Fatalf("Resolve table body: %v", err)
+	}
+	if res.Effective != ModeTest || res.RunPattern != "^TestTable$" {
+		t.Fatalf("effective = %q, run pattern = %q", res.Effective, res.RunPattern)
+	}
+}
+
+func TestResolveMapTableCases(t *testing.T) {
+	file := testutil.FixturePath(t, "sample", "table_test.go")
+
+	cases := []struct {
+		marker string
+		want   string
+	}{
+		{marker: "map_inline", want: "^TestMapTable$/^empty$"},
+		{marker: "map_local", want: "^TestMapTable$/^local-key$"},
+		{marker: "map_package", want: "^TestMapTable$/^pkg-one$"},
+		{marker: "map_body", want: "^TestMapTable$"},
+	}
+	for _, tc := range cases {
+		res, err := Resolve(ModeAuto, file, testutil.MarkerLine(t, file, tc.marker), ResolveOptions{})
+		if err != nil {
+			t.Fatalf("Resolve %s: %v", tc.marker, err)
+		}
+		if res.RunPattern != tc.want {
+			t.Fatalf("%s: run pattern = %q, want %q", tc.marker, res.RunPattern, tc.want)
+		}
+	}
+}
+
+func TestResolveRewritesSubtestNames(t *testing.T) {
+	file := testutil.FixturePath(t, "sample", "names_test.go")
+
+	cases := []struct {
+		marker string
+		want   string
+	}{
+		{marker: "names_space", want: "^TestNames$/^adds_two_numbers$"},
+		{marker: "names_escape", want: `^TestNames$/^bell\\a$`},
+		{marker: "names_dup_first", want: "^TestNames$/^dup$"},
+		{marker: "names_dup_second", want: "^TestNames$/^dup#01$"},
+		{marker: "names_dup_third", want: "^TestNames$/^dup#02$"},
+		{marker: "names_empty", want: "^TestNames$/^#00$"},
+		{marker: "names_slash_outer", want: "^TestNames$/^in$/^out$"},
+		{marker: "names_slash_nested", want: "^TestNames$/^in$/^out$/^deep$/^er$"},
+	}
+	for _, tc := range cases {
+		res, err := Resolve(ModeLeaf, file, testutil.MarkerLine(t, file, tc.marker), ResolveOptions{})
+		if err != nil {
+			t.Fatalf("Resolve %s: %v", tc.marker, err)
+		}
+		if res.RunPattern != tc.want {
+			t.Fatalf("%s: run pattern = %q, want %q", tc.marker, res.RunPattern, tc.want)
+		}
+	}
+}
+
+func TestResolveUnrolledLoops(t *testing.T) {
+	file := testutil.FixturePath(t, "sample", "loops_test.go")
+
+	cases := []struct {
+		marker string
+		want   string
+	}{
+		{marker: "loop_strings", want: "^TestLoops$/^(green|red)$"},
+		{marker: "loop_counter", want: "^TestLoops$/^(0|1|2)$"},
+		{marker: "loop_nested", want: "^TestLoops$/^(size-16-0|size-16-1|size-8-0|size-8-1)$"},
+	}
+	for _, tc := range cases {
+		res, err := Resolve(ModeLeaf, file, testutil.MarkerLine(t, file, tc.marker), ResolveOptions{})
+		if err != nil {
+			t.Fatalf("Resolve %s: %v", tc.marker, err)
+		}
+		if res.RunPattern != tc.want {
+			t.Fatalf("%s: run pattern = %q, want %q", tc.marker, res.RunPattern, tc.want)
+		}
+	}
+
+	res, err := Resolve(ModeAuto, file, testutil.MarkerLine(t, file, "loop_counter"), ResolveOptions{})
+	if err != nil {
+		t.Fatalf("Resolve auto: %v", err)
+	}
+	if res.Effective != ModeLeaf {
+		t.Fatalf("effective = %q, want %q", res.Effective, ModeLeaf)
+	}
+}
+
+func TestResolveNamedCallbacks(t *testing.T) {
+	file := testutil.FixturePath(t, "sample", "helpers_test.go")
+
+	cases := []struct {
+		marker string
+		mode   Mode
+		want   string
+	}{
+		{marker: "helper_named", mode: ModeLeaf, want: "^TestHelpers$/^create$|^TestHelpers$/^create_again$|^TestHelpersElsewhere$/^reuse$"},
+		{marker: "helper_nested", mode: ModeLeaf, want: "^TestHelpers$/^create$/^nested$|^TestHelpers$/^create_again$/^nested$|^TestHelpersElsewhere$/^reuse$/^nested$"},
+		{marker: "helper_nested", mode: ModeTest, want: "^(TestHelpers|TestHelpersElsewhere)$"},
+		{marker: "helper_factory", mode: ModeLeaf, want: "^TestHelpers$/^double$"},
+		{marker: "helper_method", mode: ModeAuto, want: "^TestHelpers$/^method$"},
+	}
+	for _, tc := range cases {
+		res, err := Resolve(tc.mode, file, testutil.MarkerLine(t, file, tc.marker), ResolveOptions{})
+		if err != nil {
+			t.Fatalf("Resolve %s: %v", tc.marker, err)
+		}
+		if res.RunPattern != tc.want {
+			t.Fatalf("%s (%s): run pattern = %q, want %q", tc.marker, tc.mode, res.RunPattern, tc.want)
+		}
+	}
+
+	res, err := Resolve(ModeLeaf, file, testutil.MarkerLine(t, file, "helper_named"), ResolveOptions{})
+	if err != nil {
+		t.Fatalf("Resolve helper note: %v", err)
+	}
+	if len(res.Notes) != 1 || !strings.Contains(res.Notes[0], "testCreate") {
+		t.Fatalf("notes = %q", res.Notes)
+	}
+}
+
+func TestResolveClosures(t *testing.T) {
+	file := testutil.FixturePath(t, "sample", "closures_test.go")
+
+	cases := []struct {
+		marker string
+		mode   Mode
+		want   string
+	}{
+		{marker: "closure_iife", mode: ModeLeaf, want: "^TestClosures$/^iife$"},
+		{marker: "closure_goroutine", mode: ModeLeaf, want: "^TestClosures$/^goroutine$"},
+		{marker: "closure_defer", mode: ModeAuto, want: "^TestClosures$/^deferred$"},
+		{marker: "closure_lambda", mode: ModeLeaf, want: "^TestClosures$/^four$|^TestClosures$/^six$|^TestClosures$/^two$"},
+		{marker: "closure_param", mode: ModeLeaf, want: "^TestClosures$/^group$/^first$"},
+		{marker: "closure_captured", mode: ModeLeaf, want: "^TestClosures$/^inner$|^TestClosures$/^outer$"},
+	}
+	for _, tc := range cases {
+		res, err := Resolve(tc.mode, file, testutil.MarkerLine(t, file, tc.marker), ResolveOptions{})
+		if err != nil {
+			t.Fatalf("Resolve %s: %v", tc.marker, err)
+		}
+		if res.RunPattern != tc.want {
+			t.Fatalf("%s (%s): run pattern = %q, want %q", tc.marker, tc.mode, res.RunPattern, tc.want)
+		}
+	}
+}
+
+func TestResolveTypedReceivers(t *testing.T) {
+	file := testutil.FixturePath(t, "sample", "typed_test.go")
+
+	cases := []struct {
+		marker string
+		want   string
+	}{
+		{marker: "typed_alias", want: "^TestTyped$/^alias$"},
+		{marker: "typed_field", want: "^TestTyped$/^field$"},
+		{marker: "typed_embedded", want: "^TestTyped$/^embedded$"},
+		{marker: "typed_assert", want: "^TestTyped$/^asserted$"},
+		{marker: "typed_shadowed", want: "^TestTyped$/^wrapped$/^hidden$"},
+		{marker: "typed_unknown", want: "^TestTyped$"},
+		{marker: "typed_outer", want: "^TestOuter$/^a$|^TestOuter$/^b$"},
+	}
+	for _, tc := range cases {
+		res, err := Resolve(ModeAuto, file, testutil.MarkerLine(t, file, tc.marker), ResolveOptions{})
+		if err != nil {
+			t.Fatalf("Resolve %s: %v", tc.marker, err)
+		}
+		if res.RunPattern != tc.want {
+			t.Fatalf("%s: run pattern = %q, want %q", tc.marker, res.RunPattern, tc.want)
+		}
+	}
+}
+
+func TestResolveHelperWrappers(t *testing.T) {
+	file := testutil.FixturePath(t, "sample", "wrappers_test.go")
+
+	cases := []struct {
+		marker string
+		want   string
+	}{
+		{marker: "wrap_timeout", want: "^TestWrappers$/^timeout$"},
+		{marker: "wrap_nested", want: "^TestWrappers$/^retry$/^inner$"},
+		{marker: "wrap_remote", want: "^TestWrappers$/^remote$"},
+		{marker: "wrap_prefixed", want: "^TestWrappers$/^case-slow$"},
+		{marker: "wrap_configured", want: "^TestWrappers$/^configured$"},
+		{marker: "wrap_local", want: "^TestWrappers$/^alpha$"},
+		{marker: "wrap_method", want: "^TestWrappers$/^beta$"},
+	}
+	for _, tc := range cases {
+		res, err := Resolve(ModeLeaf, file, testutil.MarkerLine(t, file, tc.marker), ResolveOptions{})
+		if err != nil {
+			t.Fatalf("Resolve %s: %v", tc.marker, err)
+		}
+		if res.RunPattern != tc.want {
+			t.Fatalf("%s: run pattern = %q, want %q", tc.marker, res.RunPattern, tc.want)
+		}
+	}
+}
+
+func TestResolveImportedConstants(t *testing.T) {
+	file := testutil.FixturePath(t, "sample", "routes_test.go")
+
+	cases := []struct {
+		marker string
+		want   string
+	}{
+		{marker: "route_users", want: "^TestRoutes$/^users$"},
+		{marker: "route_orders", want: "^TestRoutes$/^orders$"},
+		{marker: "route_version", want: "^TestRoutes$/^v2$"},
+	}
+	for _, tc := range cases {
+		res, err := Resolve(ModeLeaf, file, testutil.MarkerLine(t, file, tc.marker), ResolveOptions{})
+		if err != nil {
+			t.Fatalf("Resolve %s: %v", tc.marker, err)
+		}
+		if res.RunPattern != tc.want {
+			t.Fatalf("%s: run pattern = %q, want %q", tc.marker, res.RunPattern, tc.want)
+		}
+	}
+}
+
+func TestResolveStringMethodsFromOtherFiles(t *testing.T) {
+	file := testutil.FixturePath(t, "sample", "shade_test.go")
+
+	cases := []struct {
+		marker string
+		want   string
+	}{
+		{marker: "shade_method", want: "^TestShades$/^shade-3$"},
+		{marker: "shade_alias", want: "^TestShades$/^WARM$"},
+	}
+	for _, tc := range cases {
+		res, err := Resolve(ModeLeaf, file, testutil.MarkerLine(t, file, tc.marker), ResolveOptions{})
+		if err != nil {
+			t.Fatalf("Resolve %s: %v", tc.marker, err)
+		}
+		if res.RunPattern != tc.want {
+			t.Fatalf("%s: run pattern = %q, want %q", tc.marker, res.RunPattern, tc.want)
+		}
+	}
+}
+
+func TestResolveExternalTestPackage(t *testing.T) {
+	file := testutil.FixturePath(t, "extpkg", "ext_test.go")
+
+	cases := []struct {
+		marker string
+		want   string
+	}{
+		{marker: "ext_const", want: "^TestExternal$/^hello$"},
+		{marker: "ext_stringer", want: "^TestExternal$/^fancy$"},
+		{marker: "ext_index", want: "^TestExternal$/^slow$"},
+		{marker: "ext_export", want: "^TestExternal$/^lookup$"},
+	}
+	for _, tc := range cases {
+		res, err := Resolve(ModeLeaf, file, testutil.MarkerLine(t, file, tc.marker), ResolveOptions{})
+		if err != nil {
+			t.Fatalf("Resolve %s: %v", tc.marker, err)
+		}
+		if res.RunPattern != tc.want {
+			t.Fatalf("%s: run pattern = %q, want %q", tc.marker, res.RunPattern, tc.want)
+		}
+	}
+}
+
+func TestResolveSourceLine(t *testing.T) {
+	file := testutil.FixturePath(t, "calc", "calc.go")
+
+	cases := []struct {
+		mode   Mode
+		marker string
+		want   string
+	}{
+		{mode: ModeAuto, marker: "calc_add", want: "^TestAcc$|^TestAdd$/^small$"},
+		{mode: ModeLeaf, marker: "calc_sub", want: "^TestAdd$/^large$|^TestSubExternal$/^external$"},
+		{mode: ModeTest, marker: "calc_sub", want: "^(TestAdd|TestSubExternal)$"},
+		{mode: ModeAuto, marker: "calc_push", want: "^TestAcc$"},
+		{mode: ModeAuto, marker: "calc_div", want: "^TestDiv$"},
+		{mode: ModeAuto, marker: "calc_total", want: "^TestAcc_Total$"},
+	}
+	for _, tc := range cases {
+		res, err := Resolve(tc.mode, file, testutil.MarkerLine(t, file, tc.marker), ResolveOptions{})
+		if err != nil {
+			t.Fatalf("Resolve %s %s: %v", tc.mode, tc.marker, err)
+		}
+		if res.RunPattern != tc.want {
+			t.Fatalf("%s %s: run pattern = %q, want %q", tc.mode, tc.marker, res.RunPattern, tc.want)
+		}
+	}
+
+	res, err := Resolve(ModeAuto, file, testutil.MarkerLine(t, file, "calc_unused"), ResolveOptions{})
+	if err != nil {
+		t.Fatalf("Resolve unused: %v", err)
+	}
+	if res.Mode != ModePkg || res.RunPattern != "" {
+		t.Fatalf("unused: mode = %q, run pattern = %q", res.Mode, res.RunPattern)
+	}
+
+	res, err = Resolve(ModeFile, file, 1, ResolveOptions{})
+	if err != nil {
+		t.Fatalf("Resolve file: %v", err)
+	}
+	if res.RunPattern != "^(TestAcc|TestAdd|TestSubExternal)$" {
+		t.Fatalf("file run pattern = %q", res.RunPattern)
+	}
+
+	if _, err := Resolve(ModeAuto, file, 1, ResolveOptions{}); err == nil {
+		t.Fatalf("expected error for a line outside any function")
+	}
+}
+
+func TestResolveReverseDeps(t *testing.T) {
+	file := testutil.FixturePath(t, "rdeps", "base", "base.go")
+	line := testutil.MarkerLine(t, file, "rdeps_base")
+
+	cases := []struct {
+		depth int
+		want  []string
+	}{
+		{depth: 0, want: []string{"example.com/fixturemod/rdeps/base", "example.com/fixturemod/rdeps/mid", "example.com/fixturemod/rdeps/top"}},
+		{depth: 1, want: []string{"example.com/fixturemod/rdeps/base", "example.com/fixturemod/rdeps/mid"}},
+	}
+	for _, tc := range cases {
+		res, err := Resolve(ModeRdeps, file, line, ResolveOptions{Depth: tc.depth})
+		if err != nil {
+			t.Fatalf("Resolve depth %d: %v", tc.depth, err)
+		}
+		if !slices.Equal(res.Packages, tc.want) {
+			t.Fatalf("depth %d: packages = %v, want %v", tc.depth, res.Packages, tc.want)
+		}
+		if res.ModuleRoot == "" {
+			t.Fatalf("depth %d: module root not set", tc.depth)
+		}
+	}
+}
+
+func TestResolveHonoursBuildConstraints(t *testing.T) {
+	file := testutil.FixturePath(t, "platform", "platform_test.go")
+
+	cases := []struct {
+		marker string
+		opts   ResolveOptions
+		want   string
+	}{
+		{marker: "platform_os", opts: ResolveOptions{GOOS: "linux", GOARCH: "amd64"}, want: "^TestPlatform$/^linux-name$"},
+		{marker: "platform_os", opts: ResolveOptions{GOOS: "windows", GOARCH: "amd64"}, want: "^TestPlatform$/^windows-name$"},
+		{marker: "platform_suite", opts: ResolveOptions{}, want: "^TestPlatform$/^unit$"},
+		{marker: "platform_suite", opts: ResolveOptions{BuildTags: []string{"integration"}}, want: "^TestPlatform$/^integration$"},
+	}
+	for _, tc := range cases {
+		res, err := Resolve(ModeLeaf, file, testutil.MarkerLine(t, file, tc.marker), tc.opts)
+		if err != nil {
+			t.Fatalf("Resolve %s %+v: %v", tc.marker, tc.opts, err)
+		}
+		if res.RunPattern != tc.want {
+			t.Fatalf("%s %+v: run pattern = %q, want %q", tc.marker, tc.opts, res.RunPattern, tc.want)
+		}
+	}
+
+	tagged := testutil.FixturePath(t, "platform", "suite_integration_test.go")
+	line := testutil.MarkerLine(t, tagged, "platform_integration")
+	res, err := Resolve(ModeTest, tagged, line, ResolveOptions{BuildTags: []string{"integration"}})
+	if err != nil {
+		t.Fatalf("Resolve tagged: %v", err)
+	}
+	if res.RunPattern != "^TestIntegrationOnly$" {
+		t.Fatalf("tagged run pattern = %q", res.RunPattern)
+	}
+	if len(res.BuildTags) != 0 {
+		t.Fatalf("expected no inferred tags when -tags already satisfies the file, got %v", res.BuildTags)
+	}
+
+	remote := testutil.FixturePath(t, "platform", "remote_windows_test.go")
+	remoteLine := testutil.MarkerLine(t, remote, "platform_remote")
+	if _, err := Resolve(ModeLeaf, remote, remoteLine, ResolveOptions{GOOS: "linux", GOARCH: "amd64"}); err == nil || !strings.Contains(err.Error(), "excluded by build constraints") {
+		t.Fatalf("expected build constraint error, got %v", err)
+	}
+}
+
+func TestResolveInfersBuildTags(t *testing.T) {
+	tagged := testutil.FixturePath(t, "platform", "suite_integration_test.go")
+	res, err := Resolve(ModeTest, tagged, testutil.MarkerLine(t, tagged, "platform_integration"), ResolveOptions{})
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if res.RunPattern != "^TestIntegrationOnly$" {
+		t.Fatalf("run pattern = %q", res.RunPattern)
+	}
+	if !slices.Equal(res.BuildTags, []string{"integration"}) {
+		t.Fatalf("inferred tags = %v", res.BuildTags)
+	}
+	if len(res.Notes) == 0 || !strings.Contains(res.Notes[0], "inferred -tags integration") {
+		t.Fatalf("expected inferred tags note, got %v", res.Notes)
+	}
+
+	remote := testutil.FixturePath(t, "platform", "remote_windows_test.go")
+	res, err = Resolve(ModeLeaf, remote, testutil.MarkerLine(t, remote, "platform_remote"), ResolveOptions{GOOS: "windows", GOARCH: "amd64"})
+	if err != nil {
+		t.Fatalf("Resolve remote: %v", err)
+	}
+	if res.RunPattern != "^TestRemoteWindows$/^dial$" || !slices.Equal(res.BuildTags, []string{"e2e"}) {
+		t.Fatalf("remote = %q tags %v", res.RunPattern, res.BuildTags)
+	}
+}
+
+func TestInferBuildTags(t *testing.T) {
+	ctxt := buildContext(ResolveOptions{GOOS: "linux", GOARCH: "amd64"})
+	cases := []struct {
+		expr string
+		want []string
+		ok   bool
+	}{
+		{expr: "//go:build linux", ok: true},
+		{expr: "//go:build integration", want: []string{"integration"}, ok: true},
+		{expr: "//go:build integration && !race", want: []string{"integration"}, ok: true},
+		{expr: "//go:build (a && b) || c", want: []string{"c"}, ok: true},
+		{expr: "//go:build e2e && windows", ok: false},
+		{expr: "//go:build ignore", ok: false},
+	}
+	for _, tc := range cases {
+		expr, err := constraint.Parse(tc.expr)
+		if err != nil {
+			t.Fatalf("parse %q: %v", tc.expr, err)
+		}
+		got, ok := inferBuildTags(expr, ctxt)
+		if ok != tc.ok || !slices.Equal(got, tc.want) {
+			t.Fatalf("%s: got %v %v, want %v %v", tc.expr, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestResolveBenchmarks(t *testing.T) {
+	file := testutil.FixturePath(t, "sample", "bench_test.go")
+
+	res, err := Resolve(ModeLeaf, file, testutil.MarkerLine(t, file, "bench_nested"), ResolveOptions{})
+	if err != nil {
+		t.Fatalf("Resolve leaf: %v", err)
+	}
+	if res.RunPattern != "" || res.BenchPattern != "^BenchmarkAnswer$/^large$/^nested$" {
+		t.Fatalf("run pattern = %q, bench pattern = %q", res.RunPattern, res.BenchPattern)
+	}
+
+	res, err = Resolve(ModeParent, file, testutil.MarkerLine(t, file, "bench_nested"), ResolveOptions{ParentUp: 1})
+	if err != nil {
+		t.Fatalf("Resolve parent: %v", err)
+	}
+	if res.BenchPattern != "^BenchmarkAnswer$/^large$" {
+		t.Fatalf("bench pattern = %q", res.BenchPattern)
+	}
+
+	res, err = Resolve(ModeAuto, file, testutil.MarkerLine(t, file, "bench_plain"), ResolveOptions{})
+	if err != nil {
+		t.Fatalf("Resolve auto: %v", err)
+	}
+	if res.Effective != ModeTest || res.BenchPattern != "^BenchmarkPlain$" {
+		t.Fatalf("effective = %q, bench pattern = %q", res.Effective, res.BenchPattern)
+	}
+
+	res, err = Resolve(ModeFile, file, 1, ResolveOptions{})
+	if err != nil {
+		t.Fatalf("Resolve file: %v", err)
+	}
+	if res.RunPattern != "" || res.BenchPattern != "^(BenchmarkAnswer|BenchmarkPlain)$" {
+		t.Fatalf("run pattern = %q, bench pattern = %q", res.RunPattern, res.BenchPattern)
+	}
+}
+
+func TestResolveFuzzTargets(t *testing.T) {
+	file := testutil.FixturePath(t, "sample", "fuzz_test.go")
+
+	res, err := Resolve(ModeAuto, file, testutil.MarkerLine(t, file, "fuzz_seed"), ResolveOptions{})
+	if err != nil {
+		t.Fatalf("Resolve seed: %v", err)
+	}
+	if res.RunPattern != "^FuzzEcho$/^seed#1$" {
+		t.Fatalf("run pattern = %q", res.RunPattern)
+	}
+
+	res, err = Resolve(ModeAuto, file, testutil.MarkerLine(t, file, "fuzz_body"), ResolveOptions{})
+	if err != nil {
+		t.Fatalf("Resolve body: %v", err)
+	}
+	if res.RunPattern != "^FuzzEcho$" || res.FuzzPattern != "" {
+		t.Fatalf("run pattern = %q, fuzz pattern = %q", res.RunPattern, res.FuzzPattern)
+	}
+
+	res, err = Resolve(ModeAuto, file, testutil.MarkerLine(t, file, "fuzz_unordered"), ResolveOptions{})
+	if err != nil {
+		t.Fatalf("Resolve unordered seed: %v", err)
+	}
+	if res.Effective != ModeTest || res.RunPattern != "^FuzzLooped$" {
+		t.Fatalf("effective = %q, run pattern = %q", res.Effective, res.RunPattern)
+	}
+
+	res, err = Resolve(ModeFuzz, file, testutil.MarkerLine(t, file, "fuzz_seed"), ResolveOptions{})
+	if err != nil {
+		t.Fatalf("Resolve fuzz: %v", err)
+	}
+	if res.RunPattern != "^FuzzEcho$" || res.FuzzPattern != "^FuzzEcho$" {
+		t.Fatalf("run pattern = %q, fuzz pattern = %q", res.RunPattern, res.FuzzPattern)
+	}
+
+	testFile := testutil.FixtureFile(t)
+	if _, err := Resolve(ModeFuzz, testFile, testutil.MarkerLine(t, testFile, "inner"), ResolveOptions{}); err == nil {
+		t.Fatalf("expected error outside fuzz target")
+	}
+}
+
+func TestResolveCorpusEntry(t *testing.T) {
+	file := testutil.FixturePath(t, "sample", "testdata", "fuzz", "FuzzEcho", "4f1c2a7e")
+	pkgDir := testutil.FixturePath(t, "sample")
+
+	res, err := ResolveCorpus(ModeAuto, file, "FuzzEcho", "4f1c2a7e", ResolveOptions{})
+	if err != nil {
+		t.Fatalf("ResolveCorpus auto: %v", err)
+	}
+	if res.PackageDir != pkgDir || res.RunPattern != "^FuzzEcho$/^4f1c2a7e$" {
+		t.Fatalf("package dir = %q, run pattern = %q", res.PackageDir, res.RunPattern)
+	}
+
+	if _, err := ResolveCorpus(ModeAuto, file, "FuzzMissing", "4f1c2a7e", ResolveOptions{}); err == nil {
+		t.Fatalf("expected missing fuzz target error")
+	}
+
+	entries, err := ListCorpus(pkgDir, []string{"FuzzEcho", "FuzzLooped"})
+	if err != nil {
+		t.Fatalf("ListCorpus: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Target != "FuzzEcho" || entries[0].Path != file {
+		t.Fatalf("entries = %+v", entries)
+	}
+}
+
+func TestResolveExamples(t *testing.T) {
+	file := testutil.FixturePath(t, "sample", "example_test.go")
+
+	cases := []struct {
+		marker string
+		want   string
+	}{
+		{marker: "example_func", want: "^ExampleAnswer$"},
+		{marker: "example_method", want: "^ExampleCalc_Double$"},
+		{marker: "example_suffix", want: "^Example_suffix$"},
+	}
+	for _, tc := range cases {
+		res, err := Resolve(ModeAuto, file, testutil.MarkerLine(t, file, tc.marker), ResolveOptions{})
+		if err != nil {
+			t.Fatalf("Resolve %s: %v", tc.marker, err)
+		}
+		if res.RunPattern != tc.want {
+			t.Fatalf("%s: run pattern = %q, want %q", tc.marker, res.RunPattern, tc.want)
+		}
+	}
+
+	_, err := Resolve(ModeTest, file, testutil.MarkerLine(t, file, "example_no_output"), ResolveOptions{})
+	if err == nil || !strings.Contains(err.Error(), "never runs it") {
+		t.Fatalf("expected no output error, got %v", err)
+	}
+
+	res, err := Resolve(ModeFile, file, 1, ResolveOptions{})
+	if err != nil {
+		t.Fatalf("Resolve file: %v", err)
+	}
+	want := "^(ExampleAnswer|ExampleAnswer_silent|ExampleCalc_Double|Example_suffix)$"
+	if res.RunPattern != want {
+		t.Fatalf("run pattern = %q, want %q", res.RunPattern, want)
+	}
+	if len(res.Notes) != 1 || !strings.Contains(res.Notes[0], "ExampleAnswer_silent") {
+		t.Fatalf("notes = %q", res.Notes)
+	}
+}
+
+func TestResolveTestifySuite(t *testing.T) {
+	file := testutil.TestdataPath(t, "testifymod", "accounts", "accounts_test.go")
+
+	res, err := Resolve(ModeAuto, file, testutil.MarkerLine(t, file, "deposit"), ResolveOptions{})
+	if err != nil {
+		t.Fatalf("Resolve suite method: %v", err)
+	}
+	if res.RunPattern != "^TestAccountSuite$" || res.TestifyPattern != "^TestDeposit$" {
+		t.Fatalf("run pattern = %q, testify pattern = %q", res.RunPattern, res.TestifyPattern)
+	}
+
+	res, err = Resolve(ModeLeaf, file, testutil.MarkerLine(t, file, "overdraft"), ResolveOptions{})
+	if err != nil {
+		t.Fatalf("Resolve suite subtest: %v", err)
+	}
+	if res.RunPattern != "^TestAccountSuite$/^TestWithdraw$/^overdraft$" || res.TestifyPattern != "^TestWithdraw$" {
+		t.Fatalf("run pattern = %q, testify pattern = %q", res.RunPattern, res.TestifyPattern)
+	}
+
+	res, err = Resolve(ModeAuto, file, testutil.MarkerLine(t, file, "entry"), ResolveOptions{})
+	if err != nil {
+		t.Fatalf("Resolve entry: %v", err)
+	}
+	if res.RunPattern != "^TestAccountSuite$" || res.TestifyPattern != "" {
+		t.Fatalf("run pattern = %q, testify pattern = %q", res.RunPattern, res.TestifyPattern)
+	}
+
+	if _, err := Resolve(ModeAuto, file, testutil.MarkerLine(t, file, "setup"), ResolveOptions{}); err == nil {
+		t.Fatalf("expected error for non-test suite method")
+	}
+
+	res, err = Resolve(ModeFile, file, 1, ResolveOptions{})
+	if err != nil {
+		t.Fatalf("Resolve file: %v", err)
+	}
+	if res.RunPattern != "^TestAccountSuite$" || res.TestifyPattern != "^(TestDeposit|TestWithdraw)$" {
+		t.Fatalf("run pattern = %q, testify pattern = %q", res.RunPattern, res.TestifyPattern)
+	}
+}
+
+func TestResolveFileMixingSuites(t *testing.T) {
+	file := testutil.TestdataPath(t, "testifymod", "mixed", "suites_test.go")
+
+	res, err := Resolve(ModeFile, file, 1, ResolveOptions{})
+	if err != nil {
+		t.Fatalf("Resolve file: %v", err)
+	}
+	if res.RunPattern != "^(TestCheck|TestTestify)$" {
+		t.Fatalf("run pattern = %q", res.RunPattern)
+	}
+	if res.TestifyPattern != "^TestAdd$" || res.CheckFilter != "^CountSuite\\.TestSum$" {
+		t.Fatalf("testify pattern = %q, check filter = %q", res.TestifyPattern, res.CheckFilter)
+	}
+}
+
+func TestResolveQuicktestSubtests(t *testing.T) {
+	file := testutil.TestdataPath(t, "quicktestmod", "shapes", "shapes_test.go")
+
+	cases := []struct {
+		marker string
+		mode   Mode
+		want   string
+	}{
+		{marker: "unit", mode: ModeLeaf, want: "^TestArea$/^square$/^unit$"},
+		{marker: "unit", mode: ModeParent, want: "^TestArea$/^square$"},
+		{marker: "square", mode: ModeLeaf, want: "^TestArea$/^square$"},
+		{marker: "rect", mode: ModeAuto, want: "^TestAreaDirect$/^rect$"},
+	}
+	for _, tc := range cases {
+		res, err := Resolve(tc.mode, file, testutil.MarkerLine(t, file, tc.marker), ResolveOptions{})
+		if err != nil {
+			t.Fatalf("Resolve %s: %v", tc.marker, err)
+		}
+		if res.RunPattern != tc.want {
+			t.Fatalf("%s: run pattern = %q, want %q", tc.marker, res.RunPattern, tc.want)
+		}
+	}
+}
+
+func TestResolveGocheckSuite(t *testing.T) {
+	file := testutil.TestdataPath(t, "gocheckmod", "ledger", "ledger_test.go")
+
+	res, err := Resolve(ModeAuto, file, testutil.MarkerLine(t, file, "sum"), ResolveOptions{})
+	if err != nil {
+		t.Fatalf("Resolve suite method: %v", err)
+	}
+	if res.RunPattern != "^Test$" || res.CheckFilter != "^LedgerSuite\\.TestSum$" {
+		t.Fatalf("run pattern = %q, check filter = %q", res.RunPattern, res.CheckFilter)
+	}
+
+	res, err = Resolve(ModeAuto, file, testutil.MarkerLine(t, file, "entry"), ResolveOptions{})
+	if err != nil {
+		t.Fatalf("Resolve entry: %v", err)
+	}
+	if res.RunPattern != "^Test$" || res.CheckFilter != "" {
+		t.Fatalf("run pattern = %q, check filter = %q", res.RunPattern, res.CheckFilter)
+	}
+
+	if _, err := Resolve(ModeAuto, file, testutil.MarkerLine(t, file, "setup"), ResolveOptions{}); err == nil {
+		t.Fatalf("expected error for non-test suite method")
+	}
+
+	res, err = Resolve(ModeFile, file, 1, ResolveOptions{})
+	if err != nil {
+		t.Fatalf("Resolve file: %v", err)
+	}
+	if res.RunPattern != "^Test$" || res.CheckFilter != "^(LedgerSuite\\.TestEmpty|LedgerSuite\\.TestSum)$" {
+		t.Fatalf("run pattern = %q, check filter = %q", res.RunPattern, res.CheckFilter)
+	}
+}
+
+func TestResolveGinkgoSpecs(t *testing.T) {
+	file := testutil.TestdataPath(t, "ginkgomod", "books", "books_test.go")
+
+	cases := []struct {
+		name      string
+		mode      Mode
+		marker    string
+		up        int
+		focus     string
+		focusFile string
+	}{
+		{name: "leaf", mode: ModeLeaf, marker: "short", focus: "^Books Suite Book with fewer than 300 pages is a short story$", focusFile: `books_test\.go:21`},
+		{name: "parent", mode: ModeParent, marker: "short", up: 1, focus: "^Books Suite Book with fewer than 300 pages( |$)", focusFile: `books_test\.go:20`},
+		{name: "test", mode: ModeTest, marker: "novel", focus: "^Books Suite Book( |$)", focusFile: `books_test\.go:12`},
+		{name: "dynamic", mode: ModeAuto, marker: "dynamic_it", focusFile: `books_test\.go:27`},
+		{name: "table body", mode: ModeAuto, marker: "table_body", focus: "^Books Suite Book categories( |$)", focusFile: `books_test\.go:33`},
+		{name: "table entry", mode: ModeAuto, marker: "table_entry", focus: "^Books Suite Book categories tiny$", focusFile: `books_test\.go:37`},
+	}
+	for _, tc := range cases {
+		res, err := Resolve(tc.mode, file, testutil.MarkerLine(t, file, tc.marker), ResolveOptions{ParentUp: tc.up})
+		if err != nil {
+			t.Fatalf("%s: Resolve: %v", tc.name, err)
+		}
+		if res.RunPattern != "^TestBooks$" || res.GinkgoFocus != tc.focus || res.GinkgoFocusFile != tc.focusFile {
+			t.Fatalf("%s: run = %q, focus = %q, focus-file = %q", tc.name, res.RunPattern, res.GinkgoFocus, res.GinkgoFocusFile)
+		}
+	}
+
+	res, err := Resolve(ModeFile, file, 1, ResolveOptions{})
+	if err != nil {
+		t.Fatalf("Resolve file: %v", err)
+	}
+	if res.RunPattern != "^TestBooks$" || res.GinkgoFocus != "" || res.GinkgoFocusFile != `books_test\.go` {
+		t.Fatalf("run = %q, focus = %q, focus-file = %q", res.RunPattern, res.GinkgoFocus, res.GinkgoFocusFile)
+	}
+}
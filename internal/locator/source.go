@@ -0,0 +1,251 @@
+package locator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/loheagn/gun/internal/config"
+	"github.com/loheagn/gun/internal/errs"
+)
+
+type sourceRef struct {
+	caller types.Object
+	file   *ast.File
+	line   int
+	test   bool
+}
+
+func resolveSource(res Resolution, mode Mode, filePath string, line int, opts ResolveOptions) (Resolution, error) {
+	if mode == ModeFuzz {
+		return Resolution{}, errs.New(errs.CodeUsage, "fuzz needs a line inside a FuzzXxx in a _test.go file", nil)
+	}
+	cfg, err := config.Load(filepath.Dir(filePath))
+	if err != nil {
+		return Resolution{}, err
+	}
+	pkg, err := loadPackageTypes(sourceProbe(filePath, opts), opts)
+	if err != nil {
+		return Resolution{}, err
+	}
+	files := append(slices.Clone(pkg.files), pkg.internal...)
+	source := fileAt(pkg.fset, files, filePath)
+	if source == nil {
+		return Resolution{}, errs.New(errs.CodeUsage, fmt.Sprintf("%s is excluded by build constraints for %s/%s; pass -tags or --goos/--goarch", filepath.Base(filePath), pkg.build.GOOS, pkg.build.GOARCH), nil)
+	}
+
+	decls := sourceFuncs(pkg.fset, source, mode, line)
+	if len(decls) == 0 {
+		if mode == ModeFile {
+			return Resolution{}, errs.New(errs.CodeUsage, "no functions found in file", nil)
+		}
+		return Resolution{}, errs.New(errs.CodeUsage, fmt.Sprintf("line %d is not inside any function; try file/pkg/project", line), nil)
+	}
+	label := fmt.Sprintf("functions in %s", filepath.Base(filePath))
+	if mode != ModeFile {
+		label = funcLabel(decls[0])
+	}
+	targets := make([]types.Object, 0, len(decls))
+	for _, decl := range decls {
+		if obj := pkg.info.Defs[decl.Name]; obj != nil {
+			targets = append(targets, obj)
+		}
+	}
+
+	pathMode := mode
+	switch mode {
+	case ModeLeaf:
+		pathMode = ModeAuto
+	case ModeFile:
+		pathMode = ModeTest
+	}
+	ctx := newEvalContext(pkg)
+	suites := findPackageSuites(files, pkg.info)
+	scanned := make(map[*ast.File][]*Scope)
+	var paths [][]*Scope
+	res.Effective = ModeTest
+	for _, ref := range testReferences(pkg.fset, pkg.info, files, targets) {
+		scopes, ok := scanned[ref.file]
+		if !ok {
+			scopes = scanTests(ref.file, pkg.fset, ctx, suites, cfg).Tests
+			scanned[ref.file] = scopes
+		}
+		for _, path := range innermostPaths(scopes, pkg.fset.Position(ref.file.Pos()).Filename, ref.line) {
+			if path[0].Kind != ScopeKindTest {
+				continue
+			}
+			selected, effective, err := selectPath(pathMode, path, opts.ParentUp)
+			if err != nil {
+				return Resolution{}, err
+			}
+			if effective != ModeTest {
+				res.Effective = effective
+			}
+			paths = append(paths, selected)
+		}
+	}
+
+	if len(paths) > 0 {
+		res.RunPattern = buildPathsPattern(paths)
+		res.Notes = append(res.Notes, fmt.Sprintf("%s is referenced by %s", label, strings.Join(topNames(paths), ", ")))
+		return res, nil
+	}
+	if names := conventionTests(pkg.fset, files, decls); len(names) > 0 {
+		res.RunPattern = buildAlternationPattern(names)
+		res.Notes = append(res.Notes, fmt.Sprintf("no test references %s; running %s by naming convention", label, strings.Join(names, ", ")))
+		return res, nil
+	}
+	res.Mode = ModePkg
+	res.Effective = ModePkg
+	res.Notes = append(res.Notes, fmt.Sprintf("no test references %s; running the whole package", label))
+	return res, nil
+}
+
+func sourceProbe(filePath string, opts ResolveOptions) string {
+	f, err := parser.ParseFile(token.NewFileSet(), filePath, nil, parser.PackageClauseOnly)
+	if err != nil {
+		return filePath
+	}
+	dir := filepath.Dir(filePath)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return filePath
+	}
+	ctxt := buildContext(opts)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		if match, err := ctxt.MatchFile(dir, name); err != nil || !match {
+			continue
+		}
+		test, err := parser.ParseFile(token.NewFileSet(), filepath.Join(dir, name), nil, parser.PackageClauseOnly)
+		if err == nil && test.Name.Name == f.Name.Name+"_test" {
+			return filepath.Join(dir, name)
+		}
+	}
+	return filePath
+}
+
+func fileAt(fset *token.FileSet, files []*ast.File, filePath string) *ast.File {
+	abs, err := filepath.Abs(filePath)
+	if err != nil {
+		return nil
+	}
+	for _, file := range files {
+		if fset.Position(file.Pos()).Filename == filepath.Clean(abs) {
+			return file
+		}
+	}
+	return nil
+}
+
+func sourceFuncs(fset *token.FileSet, file *ast.File, mode Mode, line int) []*ast.FuncDecl {
+	var decls []*ast.FuncDecl
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil {
+			continue
+		}
+		if mode == ModeFile {
+			decls = append(decls, fn)
+			continue
+		}
+		if fset.Position(fn.Pos()).Line <= line && line <= fset.Position(fn.End()).Line {
+			return []*ast.FuncDecl{fn}
+		}
+	}
+	return decls
+}
+
+func funcLabel(fn *ast.FuncDecl) string {
+	if recv, _ := receiverTypeName(fn.Recv); recv != "" {
+		return recv + "." + fn.Name.Name
+	}
+	return fn.Name.Name
+}
+
+func testReferences(fset *token.FileSet, info *types.Info, files []*ast.File, targets []types.Object) []sourceRef {
+	callers := make(map[types.Object][]sourceRef)
+	for _, file := range files {
+		testFile := strings.HasSuffix(fset.Position(file.Pos()).Filename, "_test.go")
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Body == nil {
+				continue
+			}
+			top, ok := matchTopLevelName(fn.Name.Name)
+			test := testFile && fn.Recv == nil && ok && top.kind == ScopeKindTest
+			caller := info.Defs[fn.Name]
+			ast.Inspect(fn.Body, func(n ast.Node) bool {
+				ident, ok := n.(*ast.Ident)
+				if !ok {
+					return true
+				}
+				callee, ok := info.Uses[ident].(*types.Func)
+				if !ok {
+					return true
+				}
+				ref := sourceRef{caller: caller, file: file, line: fset.Position(ident.Pos()).Line, test: test}
+				callers[callee.Origin()] = append(callers[callee.Origin()], ref)
+				return true
+			})
+		}
+	}
+
+	var refs []sourceRef
+	seen := make(map[types.Object]bool)
+	queue := slices.Clone(targets)
+	for len(queue) > 0 {
+		obj := queue[0]
+		queue = queue[1:]
+		if seen[obj] {
+			continue
+		}
+		seen[obj] = true
+		for _, ref := range callers[obj] {
+			switch {
+			case ref.test:
+				refs = append(refs, ref)
+			case ref.caller != nil:
+				queue = append(queue, ref.caller)
+			}
+		}
+	}
+	return refs
+}
+
+func conventionTests(fset *token.FileSet, files []*ast.File, decls []*ast.FuncDecl) []string {
+	var prefixes []string
+	for _, decl := range decls {
+		prefixes = append(prefixes, "Test"+strings.ReplaceAll(funcLabel(decl), ".", "_"))
+	}
+	var names []string
+	for _, file := range files {
+		if !strings.HasSuffix(fset.Position(file.Pos()).Filename, "_test.go") {
+			continue
+		}
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil || fn.Body == nil {
+				continue
+			}
+			for _, prefix := range prefixes {
+				if fn.Name.Name == prefix || strings.HasPrefix(fn.Name.Name, prefix+"_") {
+					names = append(names, fn.Name.Name)
+					break
+				}
+			}
+		}
+	}
+	sort.Strings(names)
+	return slices.Compact(names)
+}
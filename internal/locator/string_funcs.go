@@ -0,0 +1,423 @@
+package locator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+)
+
+type stringFunc struct {
+	params   int
+	variadic bool
+	fold     func(args []any) (string, bool)
+}
+
+var stringFuncs = map[string]map[string]stringFunc{
+	"fmt": {
+		"Sprintf": {params: 1, variadic: true, fold: foldSprintf},
+		"Sprint":  {variadic: true, fold: foldSprint},
+	},
+	"strconv": {
+		"Itoa":       {params: 1, fold: foldItoa},
+		"FormatInt":  {params: 2, fold: foldFormatInt},
+		"FormatBool": {params: 1, fold: foldFormatBool},
+		"Quote":      {params: 1, fold: foldQuote},
+	},
+	"strings": {
+		"Join":    {params: 2, fold: foldJoin},
+		"ToUpper": {params: 1, fold: foldToUpper},
+		"ToLower": {params: 1, fold: foldToLower},
+	},
+}
+
+func foldSprintf(args []any) (string, bool) {
+	format, ok := args[0].(string)
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf(format, args[1:]...), true
+}
+
+func foldSprint(args []any) (string, bool) {
+	return fmt.Sprint(args...), true
+}
+
+func foldItoa(args []any) (string, bool) {
+	i, ok := args[0].(int)
+	if !ok {
+		return "", false
+	}
+	return strconv.Itoa(i), true
+}
+
+func foldFormatInt(args []any) (string, bool) {
+	i, ok := args[0].(int)
+	if !ok {
+		return "", false
+	}
+	base, ok := args[1].(int)
+	if !ok || base < 2 || base > 36 {
+		return "", false
+	}
+	return strconv.FormatInt(int64(i), base), true
+}
+
+func foldFormatBool(args []any) (string, bool) {
+	b, ok := args[0].(bool)
+	if !ok {
+		return "", false
+	}
+	return strconv.FormatBool(b), true
+}
+
+func foldQuote(args []any) (string, bool) {
+	s, ok := args[0].(string)
+	if !ok {
+		return "", false
+	}
+	return strconv.Quote(s), true
+}
+
+func foldJoin(args []any) (string, bool) {
+	elems, ok := args[0].([]string)
+	if !ok {
+		return "", false
+	}
+	sep, ok := args[1].(string)
+	if !ok {
+		return "", false
+	}
+	return strings.Join(elems, sep), true
+}
+
+func foldToUpper(args []any) (string, bool) {
+	s, ok := args[0].(string)
+	if !ok {
+		return "", false
+	}
+	return strings.ToUpper(s), true
+}
+
+func foldToLower(args []any) (string, bool) {
+	s, ok := args[0].(string)
+	if !ok {
+		return "", false
+	}
+	return strings.ToLower(s), true
+}
+
+func lookupStringFunc(fun ast.Expr, ctx *evalContext) (stringFunc, bool) {
+	if ctx == nil || ctx.info == nil {
+		return stringFunc{}, false
+	}
+	switch f := fun.(type) {
+	case *ast.SelectorExpr:
+		ident, ok := f.X.(*ast.Ident)
+		if !ok {
+			return stringFunc{}, false
+		}
+		pkgName, ok := ctx.info.Uses[ident].(*types.PkgName)
+		if !ok {
+			return stringFunc{}, false
+		}
+		fn, ok := stringFuncs[pkgName.Imported().Path()][f.Sel.Name]
+		return fn, ok
+	case *ast.Ident:
+		obj, ok := ctx.info.Uses[f].(*types.Func)
+		if !ok || obj.Pkg() == nil {
+			return stringFunc{}, false
+		}
+		fn, ok := stringFuncs[obj.Pkg().Path()][f.Name]
+		return fn, ok
+	}
+	return stringFunc{}, false
+}
+
+func (f stringFunc) call(call *ast.CallExpr, ctx *evalContext) (string, bool) {
+	if call.Ellipsis != token.NoPos {
+		return "", false
+	}
+	if len(call.Args) < f.params || (!f.variadic && len(call.Args) != f.params) {
+		return "", false
+	}
+	args := make([]any, 0, len(call.Args))
+	for _, arg := range call.Args {
+		v, ok := evalArg(arg, ctx)
+		if !ok {
+			return "", false
+		}
+		args = append(args, v)
+	}
+	return f.fold(args)
+}
+
+func evalArg(expr ast.Expr, ctx *evalContext) (any, bool) {
+	if tv, ok := ctx.info.Types[expr]; ok && tv.Type != nil {
+		if slice, ok := tv.Type.Underlying().(*types.Slice); ok {
+			if basic, ok := slice.Elem().Underlying().(*types.Basic); ok && basic.Info()&types.IsString != 0 {
+				return evalStrings(expr, ctx)
+			}
+			return nil, false
+		}
+	}
+	return evalAny(expr, ctx)
+}
+
+func evalStrings(expr ast.Expr, ctx *evalContext) ([]string, bool) {
+	lit := compositeLitOf(expr, ctx, 0)
+	if lit == nil || !isSliceLit(lit, ctx) {
+		return nil, false
+	}
+	out := make([]string, 0, len(lit.Elts))
+	for _, elt := range lit.Elts {
+		if _, keyed := elt.(*ast.KeyValueExpr); keyed {
+			return nil, false
+		}
+		s, ok := evalString(elt, ctx)
+		if !ok {
+			return nil, false
+		}
+		out = append(out, s)
+	}
+	return out, true
+}
+
+func conversionType(call *ast.CallExpr, ctx *evalContext) (*types.Basic, bool) {
+	if ctx == nil || ctx.info == nil || len(call.Args) != 1 {
+		return nil, false
+	}
+	tv, ok := ctx.info.Types[call.Fun]
+	if !ok || !tv.IsType() {
+		return nil, false
+	}
+	basic, ok := tv.Type.Underlying().(*types.Basic)
+	return basic, ok
+}
+
+func evalStringConversion(target *types.Basic, arg ast.Expr, ctx *evalContext) (string, bool) {
+	if target.Info()&types.IsString == 0 {
+		return "", false
+	}
+	tv, ok := ctx.info.Types[arg]
+	if !ok || tv.Type == nil {
+		return "", false
+	}
+	basic, ok := tv.Type.Underlying().(*types.Basic)
+	if !ok {
+		return "", false
+	}
+	switch {
+	case basic.Info()&types.IsString != 0:
+		return evalString(arg, ctx)
+	case basic.Info()&types.IsInteger != 0:
+		i, ok := evalInt(arg, ctx)
+		if !ok {
+			return "", false
+		}
+		return string(rune(i)), true
+	}
+	return "", false
+}
+
+func indexedElement(e *ast.IndexExpr, ctx *evalContext) ast.Expr {
+	if ctx == nil || ctx.info == nil {
+		return nil
+	}
+	lit := compositeLitOf(e.X, ctx, 0)
+	switch {
+	case lit == nil:
+		return nil
+	case isMapLit(lit, ctx):
+		key, ok := evalAny(e.Index, ctx)
+		if !ok {
+			return nil
+		}
+		for _, elt := range lit.Elts {
+			kv, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				return nil
+			}
+			k, ok := evalAny(kv.Key, ctx)
+			if !ok {
+				return nil
+			}
+			if k == key {
+				return kv.Value
+			}
+		}
+	case isSliceLit(lit, ctx):
+		index, ok := evalInt(e.Index, ctx)
+		if !ok {
+			return nil
+		}
+		var pos int64
+		for _, elt := range lit.Elts {
+			value := elt
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				if pos, ok = evalInt(kv.Key, ctx); !ok {
+					return nil
+				}
+				value = kv.Value
+			}
+			if pos == index {
+				return value
+			}
+			pos++
+		}
+	}
+	return nil
+}
+
+type stringerValue struct {
+	value any
+	text  string
+}
+
+func (v stringerValue) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 's', 'q', 'x', 'X':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), v.text)
+		return
+	case 'v':
+		if !f.Flag('#') {
+			fmt.Fprintf(f, fmt.FormatString(f, verb), v.text)
+			return
+		}
+	}
+	fmt.Fprintf(f, fmt.FormatString(f, verb), v.value)
+}
+
+func stringMethodOf(typ types.Type) *types.Func {
+	obj, _, _ := types.LookupFieldOrMethod(typ, false, nil, "String")
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return nil
+	}
+	sig := fn.Type().(*types.Signature)
+	if sig.Params().Len() != 0 || sig.Results().Len() != 1 {
+		return nil
+	}
+	if basic, ok := sig.Results().At(0).Type().(*types.Basic); !ok || basic.Kind() != types.String {
+		return nil
+	}
+	return fn
+}
+
+func hasStringMethod(expr ast.Expr, ctx *evalContext) bool {
+	if ctx == nil || ctx.info == nil {
+		return false
+	}
+	tv, ok := ctx.info.Types[expr]
+	return ok && tv.Type != nil && stringMethodOf(tv.Type) != nil
+}
+
+func evalStringer(expr ast.Expr, ctx *evalContext) (any, bool) {
+	text, ok := evalStringMethod(expr, ctx)
+	if !ok {
+		return nil, false
+	}
+	value, ok := evalPlain(expr, ctx)
+	if !ok {
+		return nil, false
+	}
+	return stringerValue{value: value, text: text}, true
+}
+
+func evalStringMethod(recv ast.Expr, ctx *evalContext) (string, bool) {
+	if ctx == nil || ctx.info == nil || ctx.depth >= maxValueDepth {
+		return "", false
+	}
+	tv, ok := ctx.info.Types[recv]
+	if !ok || tv.Type == nil {
+		return "", false
+	}
+	method := stringMethodOf(tv.Type)
+	if method == nil {
+		return "", false
+	}
+	decl := ctx.funcs[method]
+	if decl == nil || decl.Recv == nil || len(decl.Recv.List) != 1 {
+		return "", false
+	}
+	value, ok := constValue(recv, ctx)
+	if !ok {
+		return "", false
+	}
+	inner := *ctx
+	inner.depth++
+	inner.bindings = make(map[types.Object]constant.Value, len(ctx.bindings)+1)
+	for obj, v := range ctx.bindings {
+		inner.bindings[obj] = v
+	}
+	if names := decl.Recv.List[0].Names; len(names) == 1 {
+		if obj := ctx.info.Defs[names[0]]; obj != nil {
+			inner.bindings[obj] = value
+		}
+	}
+	return evalReturnString(decl.Body.List, &inner)
+}
+
+func evalReturnString(stmts []ast.Stmt, ctx *evalContext) (string, bool) {
+	for _, stmt := range stmts {
+		switch s := stmt.(type) {
+		case *ast.ReturnStmt:
+			if len(s.Results) != 1 {
+				return "", false
+			}
+			return evalString(s.Results[0], ctx)
+		case *ast.SwitchStmt:
+			body, matched, ok := selectSwitchCase(s, ctx)
+			if !ok {
+				return "", false
+			}
+			if matched {
+				return evalReturnString(body, ctx)
+			}
+		default:
+			return "", false
+		}
+	}
+	return "", false
+}
+
+func selectSwitchCase(s *ast.SwitchStmt, ctx *evalContext) ([]ast.Stmt, bool, bool) {
+	if s.Init != nil || s.Tag == nil {
+		return nil, false, false
+	}
+	tag, ok := constValue(s.Tag, ctx)
+	if !ok {
+		return nil, false, false
+	}
+	var fallback *ast.CaseClause
+	for _, stmt := range s.Body.List {
+		clause := stmt.(*ast.CaseClause)
+		if clause.List == nil {
+			fallback = clause
+			continue
+		}
+		for _, expr := range clause.List {
+			v, ok := constValue(expr, ctx)
+			if !ok || !comparableConstants(tag, v) {
+				return nil, false, false
+			}
+			if constant.Compare(tag, token.EQL, v) {
+				return clause.Body, true, true
+			}
+		}
+	}
+	if fallback != nil {
+		return fallback.Body, true, true
+	}
+	return nil, false, true
+}
+
+func comparableConstants(a, b constant.Value) bool {
+	numeric := func(v constant.Value) bool {
+		return v.Kind() == constant.Int || v.Kind() == constant.Float
+	}
+	return a.Kind() == b.Kind() || (numeric(a) && numeric(b))
+}
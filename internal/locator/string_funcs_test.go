@@ -0,0 +1,126 @@
+package locator
+
+import (
+	"fmt"
+	"go/ast"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const evalSource = `package sample
+
+import (
+	"fmt"
+	str "strings"
+	"strconv"
+)
+
+type Color int
+
+const (
+	Red Color = iota
+	Green
+	Blue
+)
+
+func (c Color) String() string {
+	switch c {
+	case Red:
+		return "red"
+	case Green:
+		return "green"
+	}
+	return "color-" + strconv.Itoa(int(c))
+}
+
+type Mode string
+
+const Fast Mode = "fast"
+
+func (m Mode) String() string {
+	return "mode:" + string(m)
+}
+
+var sizes = [...]string{"s", "m", "l"}
+
+var levels = map[string]int{"low": 1, "high": 9}
+
+var parts = []string{"a", "b"}
+
+const letter = 'x'
+
+func fold() {
+	offset := 2
+%s
+}
+`
+
+func TestEvalStringHelpers(t *testing.T) {
+	cases := []struct {
+		expr string
+		want string
+	}{
+		{expr: `fmt.Sprint("a", 1, 2, "b")`, want: "a1 2b"},
+		{expr: `fmt.Sprint(Red)`, want: "red"},
+		{expr: `fmt.Sprintf("%v-%d", Green, Green)`, want: "green-1"},
+		{expr: `fmt.Sprintf("%s", Blue)`, want: "color-2"},
+		{expr: `str.Join(parts, "+")`, want: "a+b"},
+		{expr: `str.Join([]string{"x", "y", "z"}, "")`, want: "xyz"},
+		{expr: `str.ToUpper("mixed Case")`, want: "MIXED CASE"},
+		{expr: `str.ToLower("MiXeD")`, want: "mixed"},
+		{expr: `strconv.Itoa(len(parts))`, want: ""},
+		{expr: `strconv.Itoa(offset)`, want: "2"},
+		{expr: `strconv.FormatInt(255, 16)`, want: "ff"},
+		{expr: `strconv.FormatInt(int64(offset), 2)`, want: "10"},
+		{expr: `strconv.Quote("say \"hi\"")`, want: `"say \"hi\""`},
+		{expr: `strconv.FormatBool(true)`, want: "true"},
+		{expr: `string(rune(letter + 1))`, want: "y"},
+		{expr: `string(rune('a' + offset))`, want: "c"},
+		{expr: `strconv.Itoa(offset*10 - 1)`, want: "19"},
+		{expr: `Red.String()`, want: "red"},
+		{expr: `Fast.String()`, want: "mode:fast"},
+		{expr: `fmt.Sprint(Fast)`, want: "mode:fast"},
+		{expr: `string(Fast)`, want: "fast"},
+		{expr: `sizes[1]`, want: "m"},
+		{expr: `"level-" + strconv.Itoa(levels["high"])`, want: "level-9"},
+	}
+
+	var body strings.Builder
+	for i, tc := range cases {
+		fmt.Fprintf(&body, "\tv%d := %s\n\t_ = v%d\n", i, tc.expr, i)
+	}
+	dir := t.TempDir()
+	file := filepath.Join(dir, "eval.go")
+	if err := os.WriteFile(file, []byte(fmt.Sprintf(evalSource, body.String())), 0o644); err != nil {
+		t.Fatalf("write source: %v", err)
+	}
+	pkg, err := loadPackageTypes(file, ResolveOptions{})
+	if err != nil {
+		t.Fatalf("loadPackageTypes: %v", err)
+	}
+	ctx := newEvalContext(pkg)
+
+	exprs := make(map[string]ast.Expr)
+	ast.Inspect(pkg.target, func(n ast.Node) bool {
+		if assign, ok := n.(*ast.AssignStmt); ok && len(assign.Lhs) == 1 {
+			if ident, ok := assign.Lhs[0].(*ast.Ident); ok {
+				exprs[ident.Name] = assign.Rhs[0]
+			}
+		}
+		return true
+	})
+	for i, tc := range cases {
+		got, ok := evalString(exprs[fmt.Sprintf("v%d", i)], ctx)
+		if tc.want == "" {
+			if ok {
+				t.Errorf("%s: expected unresolvable, got %q", tc.expr, got)
+			}
+			continue
+		}
+		if !ok || got != tc.want {
+			t.Errorf("%s = %q (ok=%v), want %q", tc.expr, got, ok, tc.want)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package locator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+func subtestRunNames(scope *Scope) []string {
+	raw := scope.Alternatives
+	if len(raw) == 0 {
+		raw = []string{scope.Name}
+	}
+	if scope.Parent == nil {
+		names := make([]string, 0, len(raw))
+		for _, name := range raw {
+			names = append(names, rewriteSubtestName(name))
+		}
+		return names
+	}
+	used := make(map[string]int)
+	for _, sibling := range scope.Parent.Children {
+		if sibling.Kind != ScopeKindSubtest {
+			continue
+		}
+		runs := sibling.Alternatives
+		switch {
+		case len(runs) > 0:
+		case sibling.NameResolvable || sibling == scope:
+			runs = []string{sibling.Name}
+		default:
+			continue
+		}
+		names := make([]string, 0, len(runs))
+		for _, name := range runs {
+			names = append(names, uniqueSubtestName(used, rewriteSubtestName(name)))
+		}
+		if sibling == scope {
+			return names
+		}
+	}
+	return nil
+}
+
+func uniqueSubtestName(used map[string]int, name string) string {
+	empty := name == ""
+	for {
+		next, exists := used[name]
+		if !empty && !exists {
+			used[name] = 1
+			return name
+		}
+		used[name] = next + 1
+		name = fmt.Sprintf("%s#%02d", name, next)
+		empty = false
+	}
+}
+
+func rewriteSubtestName(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		switch {
+		case isSubtestSpace(r):
+			b.WriteByte('_')
+		case !strconv.IsPrint(r):
+			quoted := strconv.QuoteRune(r)
+			b.WriteString(quoted[1 : len(quoted)-1])
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func isSubtestSpace(r rune) bool {
+	if r < 0x2000 {
+		switch r {
+		case '\t', '\n', '\v', '\f', '\r', ' ', 0x85, 0xA0, 0x1680:
+			return true
+		}
+		return false
+	}
+	if r <= 0x200a {
+		return true
+	}
+	switch r {
+	case 0x2028, 0x2029, 0x202f, 0x205f, 0x3000:
+		return true
+	}
+	return false
+}
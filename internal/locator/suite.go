@@ -0,0 +1,120 @@
+package locator
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+)
+
+const testifySuitePath = "github.com/stretchr/testify/suite"
+
+type packageSuites struct {
+	testify map[string][]string
+	gocheck gocheckSuites
+}
+
+func findPackageSuites(files []*ast.File, info *types.Info) *packageSuites {
+	return &packageSuites{
+		testify: findSuiteEntries(files, info),
+		gocheck: findCheckSuites(files, info),
+	}
+}
+
+func findSuiteEntries(files []*ast.File, info *types.Info) map[string][]string {
+	entries := make(map[string][]string)
+	for _, file := range files {
+		aliases, dot := collectImportAliases(file, testifySuitePath)
+		if len(aliases) == 0 && !dot {
+			continue
+		}
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil || fn.Body == nil || !hasTopLevelPrefix(fn.Name.Name, "Test") {
+				continue
+			}
+			ast.Inspect(fn.Body, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok || len(call.Args) != 2 || !isPackageFunc(call.Fun, "Run", aliases, dot) {
+					return true
+				}
+				if name := suiteTypeName(call.Args[1], info); name != "" {
+					entries[name] = append(entries[name], fn.Name.Name)
+				}
+				return true
+			})
+		}
+	}
+	for name := range entries {
+		sort.Strings(entries[name])
+	}
+	return entries
+}
+
+func suiteTypeName(expr ast.Expr, info *types.Info) string {
+	if info != nil {
+		if tv, ok := info.Types[expr]; ok && tv.Type != nil {
+			typ := tv.Type
+			if ptr, ok := typ.(*types.Pointer); ok {
+				typ = ptr.Elem()
+			}
+			if named, ok := typ.(*types.Named); ok {
+				return named.Obj().Name()
+			}
+		}
+	}
+	switch e := expr.(type) {
+	case *ast.CallExpr:
+		if fun, ok := e.Fun.(*ast.Ident); ok && fun.Name == "new" && len(e.Args) == 1 {
+			return typeIdentName(e.Args[0])
+		}
+	case *ast.UnaryExpr:
+		if lit, ok := e.X.(*ast.CompositeLit); ok && e.Op == token.AND {
+			return typeIdentName(lit.Type)
+		}
+	case *ast.CompositeLit:
+		return typeIdentName(e.Type)
+	}
+	return ""
+}
+
+func receiverTypeName(recv *ast.FieldList) (string, string) {
+	if recv == nil || len(recv.List) != 1 {
+		return "", ""
+	}
+	field := recv.List[0]
+	varName := ""
+	if len(field.Names) == 1 {
+		varName = field.Names[0].Name
+	}
+	typ := field.Type
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+	return typeIdentName(typ), varName
+}
+
+func typeIdentName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e.Name
+	case *ast.IndexExpr:
+		return typeIdentName(e.X)
+	case *ast.IndexListExpr:
+		return typeIdentName(e.X)
+	default:
+		return ""
+	}
+}
+
+func isPackageFunc(fun ast.Expr, name string, aliases map[string]bool, dot bool) bool {
+	switch f := fun.(type) {
+	case *ast.SelectorExpr:
+		ident, ok := f.X.(*ast.Ident)
+		return ok && aliases[ident.Name] && f.Sel.Name == name
+	case *ast.Ident:
+		return dot && f.Name == name
+	default:
+		return false
+	}
+}
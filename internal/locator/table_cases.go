@@ -0,0 +1,172 @@
+package locator
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+const maxValueDepth = 8
+
+func tableCaseScopes(nameExpr ast.Expr, fset *token.FileSet, ctx *evalContext) []*Scope {
+	if ctx == nil || ctx.info == nil {
+		return nil
+	}
+	switch e := nameExpr.(type) {
+	case *ast.Ident:
+		obj := ctx.info.Uses[e]
+		rng := ctx.rangeStmts[obj]
+		if rng == nil || !isRangeVar(rng.Key, obj, ctx) {
+			return nil
+		}
+		lit := compositeLitOf(rng.X, ctx, 0)
+		if lit == nil || !isMapLit(lit, ctx) {
+			return nil
+		}
+		scopes := make([]*Scope, 0, len(lit.Elts))
+		for _, elt := range lit.Elts {
+			name, resolvable := "", false
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				name, resolvable = evalString(kv.Key, ctx)
+			}
+			scopes = append(scopes, caseScope(elt, name, resolvable, fset))
+		}
+		return scopes
+	case *ast.SelectorExpr:
+		ident, ok := e.X.(*ast.Ident)
+		if !ok {
+			return nil
+		}
+		obj := ctx.info.Uses[ident]
+		rng := ctx.rangeStmts[obj]
+		if rng == nil || !isRangeVar(rng.Value, obj, ctx) {
+			return nil
+		}
+		lit := compositeLitOf(rng.X, ctx, 0)
+		if lit == nil || !(isSliceLit(lit, ctx) || isMapLit(lit, ctx)) {
+			return nil
+		}
+		scopes := make([]*Scope, 0, len(lit.Elts))
+		for _, elt := range lit.Elts {
+			value := elt
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				value = kv.Value
+			}
+			name, resolvable := "", false
+			if field := structField(value, e.Sel.Name, ctx); field != nil {
+				name, resolvable = evalString(field, ctx)
+			}
+			scopes = append(scopes, caseScope(elt, name, resolvable, fset))
+		}
+		return scopes
+	default:
+		return nil
+	}
+}
+
+func caseScope(node ast.Node, name string, resolvable bool, fset *token.FileSet) *Scope {
+	scope := &Scope{
+		Name:           name,
+		Kind:           ScopeKindSubtest,
+		StartLine:      fset.Position(node.Pos()).Line,
+		EndLine:        fset.Position(node.End()).Line,
+		NameResolvable: resolvable,
+		File:           fset.Position(node.Pos()).Filename,
+	}
+	if !resolvable {
+		scope.Name = ""
+	}
+	return scope
+}
+
+func isRangeVar(expr ast.Expr, obj types.Object, ctx *evalContext) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && obj != nil && ctx.info.Defs[ident] == obj
+}
+
+func compositeLitOf(expr ast.Expr, ctx *evalContext, depth int) *ast.CompositeLit {
+	if depth > maxValueDepth {
+		return nil
+	}
+	switch e := expr.(type) {
+	case *ast.CompositeLit:
+		return e
+	case *ast.ParenExpr:
+		return compositeLitOf(e.X, ctx, depth+1)
+	case *ast.Ident:
+		value, ok := ctx.values[ctx.info.Uses[e]]
+		if !ok {
+			return nil
+		}
+		return compositeLitOf(value, ctx, depth+1)
+	case *ast.SelectorExpr:
+		if _, ok := ctx.info.Uses[e.Sel].(*types.Var); !ok {
+			return nil
+		}
+		return compositeLitOf(e.Sel, ctx, depth+1)
+	default:
+		return nil
+	}
+}
+
+func isSliceLit(lit *ast.CompositeLit, ctx *evalContext) bool {
+	if tv, ok := ctx.info.Types[lit]; ok && tv.Type != nil {
+		switch tv.Type.Underlying().(type) {
+		case *types.Slice, *types.Array:
+			return true
+		default:
+			return false
+		}
+	}
+	_, ok := lit.Type.(*ast.ArrayType)
+	return ok
+}
+
+func isMapLit(lit *ast.CompositeLit, ctx *evalContext) bool {
+	if tv, ok := ctx.info.Types[lit]; ok && tv.Type != nil {
+		_, ok := tv.Type.Underlying().(*types.Map)
+		return ok
+	}
+	_, ok := lit.Type.(*ast.MapType)
+	return ok
+}
+
+func structField(expr ast.Expr, field string, ctx *evalContext) ast.Expr {
+	if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		expr = unary.X
+	}
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok || len(lit.Elts) == 0 {
+		return nil
+	}
+	if _, keyed := lit.Elts[0].(*ast.KeyValueExpr); keyed {
+		for _, elt := range lit.Elts {
+			kv, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				continue
+			}
+			if key, ok := kv.Key.(*ast.Ident); ok && key.Name == field {
+				return kv.Value
+			}
+		}
+		return nil
+	}
+	tv, ok := ctx.info.Types[lit]
+	if !ok || tv.Type == nil {
+		return nil
+	}
+	typ := tv.Type
+	if ptr, ok := typ.Underlying().(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	st, ok := typ.Underlying().(*types.Struct)
+	if !ok {
+		return nil
+	}
+	for i := 0; i < st.NumFields() && i < len(lit.Elts); i++ {
+		if st.Field(i).Name() == field {
+			return lit.Elts[i]
+		}
+	}
+	return nil
+}
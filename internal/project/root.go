@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/loheagn/gun/internal/errs"
 )
@@ -47,3 +49,44 @@ func FindModuleRoot(startDir string) (string, error) {
 		dir = parent
 	}
 }
+
+func ModulePath(root string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(root, "go.mod"))
+	if err != nil {
+		return "", errs.New(errs.CodeUsage, fmt.Sprintf("failed to read go.mod in %q", root), err)
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] != "module" {
+			continue
+		}
+		if path, err := strconv.Unquote(fields[1]); err == nil {
+			return path, nil
+		}
+		return fields[1], nil
+	}
+	return "", errs.New(errs.CodeUsage, fmt.Sprintf("go.mod in %q has no module directive", root), nil)
+}
+
+func ImportPath(dir string) (string, error) {
+	root, err := FindModuleRoot(dir)
+	if err != nil {
+		return "", err
+	}
+	module, err := ModulePath(root)
+	if err != nil {
+		return "", err
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", errs.New(errs.CodeUsage, "failed to resolve directory", err)
+	}
+	rel, err := filepath.Rel(root, abs)
+	if err != nil {
+		return "", errs.New(errs.CodeUsage, fmt.Sprintf("%q is outside module root %q", dir, root), err)
+	}
+	if rel == "." {
+		return module, nil
+	}
+	return module + "/" + filepath.ToSlash(rel), nil
+}
@@ -44,3 +44,40 @@ func TestResolveRootOverride(t *testing.T) {
 		t.Fatalf("root = %q, want %q", got, root)
 	}
 }
+
+func TestModulePath(t *testing.T) {
+	root := t.TempDir()
+	content := "// comment\nmodule \"example.com/quoted\"\n\ngo 1.25\n"
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte(content), 0o644); err != nil {
+		t.Fatalf("write go.mod: %v", err)
+	}
+
+	got, err := ModulePath(root)
+	if err != nil {
+		t.Fatalf("ModulePath: %v", err)
+	}
+	if got != "example.com/quoted" {
+		t.Fatalf("module path = %q", got)
+	}
+}
+
+func TestImportPath(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "internal", "store")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/m\n"), 0o644); err != nil {
+		t.Fatalf("write go.mod: %v", err)
+	}
+
+	for dir, want := range map[string]string{root: "example.com/m", nested: "example.com/m/internal/store"} {
+		got, err := ImportPath(dir)
+		if err != nil {
+			t.Fatalf("ImportPath(%q): %v", dir, err)
+		}
+		if got != want {
+			t.Fatalf("ImportPath(%q) = %q, want %q", dir, got, want)
+		}
+	}
+}
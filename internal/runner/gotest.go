@@ -3,6 +3,7 @@ package runner
 import (
 	"os"
 	"os/exec"
+	"slices"
 	"strings"
 
 	"github.com/loheagn/gun/internal/errs"
@@ -12,41 +13,102 @@ import (
 type Invocation struct {
 	Dir  string
 	Args []string
+	Env  []string
 }
 
 func BuildInvocation(res locator.Resolution, passthrough []string) (Invocation, error) {
-	pkgTarget := "."
+	pkgTargets := []string{"."}
 	dir := res.PackageDir
 	useRun := false
 
 	switch res.Mode {
 	case locator.ModeProject:
-		pkgTarget = "./..."
+		pkgTargets = []string{"./..."}
+		dir = res.ModuleRoot
+	case locator.ModeRdeps:
+		pkgTargets = res.Packages
 		dir = res.ModuleRoot
 	case locator.ModePkg:
-		pkgTarget = "."
+		pkgTargets = []string{"."}
 	default:
-		pkgTarget = "."
+		pkgTargets = []string{"."}
 		useRun = true
 	}
 
-	if useRun && hasRunOverride(passthrough) {
+	if useRun && hasFlag(passthrough, "run") {
 		return Invocation{}, errs.New(errs.CodeUsage, "do not pass -run for this command; gun already selects tests", nil)
 	}
+	if useRun && res.BenchPattern != "" && hasFlag(passthrough, "bench") {
+		return Invocation{}, errs.New(errs.CodeUsage, "do not pass -bench for this command; gun already selects benchmarks", nil)
+	}
+
+	if useRun && res.FuzzPattern != "" && hasFlag(passthrough, "fuzz") {
+		return Invocation{}, errs.New(errs.CodeUsage, "do not pass -fuzz for this command; gun already selects the fuzz target", nil)
+	}
+
+	if useRun && res.TestifyPattern != "" && hasFlag(passthrough, "testify.m") {
+		return Invocation{}, errs.New(errs.CodeUsage, "do not pass -testify.m for this command; gun already selects the suite method", nil)
+	}
+
+	if useRun && (res.GinkgoFocus != "" || res.GinkgoFocusFile != "") && (hasFlag(passthrough, "ginkgo.focus") || hasFlag(passthrough, "ginkgo.focus-file")) {
+		return Invocation{}, errs.New(errs.CodeUsage, "do not pass -ginkgo.focus or -ginkgo.focus-file for this command; gun already selects specs", nil)
+	}
+
+	if useRun && res.CheckFilter != "" && hasFlag(passthrough, "check.f") {
+		return Invocation{}, errs.New(errs.CodeUsage, "do not pass -check.f for this command; gun already selects the suite method", nil)
+	}
+
+	if len(res.BuildTags) > 0 {
+		passthrough = mergeTags(passthrough, res.BuildTags)
+	}
 
 	args := []string{"test"}
 	if useRun {
-		args = append(args, "-run", res.RunPattern)
+		runPattern := res.RunPattern
+		if runPattern == "" && res.BenchPattern != "" {
+			runPattern = "^$"
+		}
+		if runPattern != "" {
+			args = append(args, "-run", runPattern)
+		}
+		if res.BenchPattern != "" {
+			args = append(args, "-bench", res.BenchPattern)
+		}
+		if res.FuzzPattern != "" {
+			args = append(args, "-fuzz", res.FuzzPattern)
+		}
+		if res.TestifyPattern != "" {
+			args = append(args, "-testify.m", res.TestifyPattern)
+		}
+		if res.GinkgoFocus != "" {
+			args = append(args, "--ginkgo.focus="+res.GinkgoFocus)
+		}
+		if res.GinkgoFocusFile != "" {
+			args = append(args, "--ginkgo.focus-file="+res.GinkgoFocusFile)
+		}
+		if res.CheckFilter != "" {
+			args = append(args, "-check.f", res.CheckFilter)
+		}
 	}
 	args = append(args, passthrough...)
-	args = append(args, pkgTarget)
+	args = append(args, pkgTargets...)
 
-	return Invocation{Dir: dir, Args: args}, nil
+	var env []string
+	if res.GOOS != "" {
+		env = append(env, "GOOS="+res.GOOS)
+	}
+	if res.GOARCH != "" {
+		env = append(env, "GOARCH="+res.GOARCH)
+	}
+	return Invocation{Dir: dir, Args: args, Env: env}, nil
 }
 
 func Run(inv Invocation) error {
 	cmd := exec.Command("go", inv.Args...)
 	cmd.Dir = inv.Dir
+	if len(inv.Env) > 0 {
+		cmd.Env = append(os.Environ(), inv.Env...)
+	}
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
@@ -56,15 +118,63 @@ func Run(inv Invocation) error {
 	return nil
 }
 
-func hasRunOverride(args []string) bool {
+func hasFlag(args []string, name string) bool {
 	for i := 0; i < len(args); i++ {
 		arg := args[i]
-		if arg == "-run" {
+		if strings.HasPrefix(arg, "--") {
+			arg = arg[1:]
+		}
+		if arg == "-"+name {
 			return true
 		}
-		if strings.HasPrefix(arg, "-run=") {
+		if strings.HasPrefix(arg, "-"+name+"=") {
 			return true
 		}
 	}
 	return false
 }
+
+func BuildTags(args []string) []string {
+	var value string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if strings.HasPrefix(arg, "--") {
+			arg = arg[1:]
+		}
+		switch {
+		case arg == "-tags" && i+1 < len(args):
+			value = args[i+1]
+			i++
+		case strings.HasPrefix(arg, "-tags="):
+			value = strings.TrimPrefix(arg, "-tags=")
+		}
+	}
+	if value == "" {
+		return nil
+	}
+	return strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' })
+}
+
+func mergeTags(args []string, tags []string) []string {
+	merged := BuildTags(args)
+	for _, tag := range tags {
+		if !slices.Contains(merged, tag) {
+			merged = append(merged, tag)
+		}
+	}
+	out := []string{"-tags", strings.Join(merged, ",")}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if strings.HasPrefix(arg, "--") {
+			arg = arg[1:]
+		}
+		switch {
+		case arg == "-tags" && i+1 < len(args):
+			i++
+		case strings.HasPrefix(arg, "-tags="):
+		default:
+			out = append(out, args[i])
+		}
+	}
+	return out
+}
@@ -26,6 +26,111 @@ func TestBuildInvocationWithRunPattern(t *testing.T) {
 	}
 }
 
+func TestBuildInvocationWithBenchPattern(t *testing.T) {
+	res := locator.Resolution{
+		Mode:         locator.ModeLeaf,
+		PackageDir:   "/tmp/pkg",
+		BenchPattern: "^BenchmarkA$/^sub$",
+	}
+	inv, err := BuildInvocation(res, []string{"-benchtime=1x"})
+	if err != nil {
+		t.Fatalf("BuildInvocation: %v", err)
+	}
+	want := []string{"test", "-run", "^$", "-bench", "^BenchmarkA$/^sub$", "-benchtime=1x", "."}
+	if !reflect.DeepEqual(inv.Args, want) {
+		t.Fatalf("args = %#v, want %#v", inv.Args, want)
+	}
+
+	if _, err := BuildInvocation(res, []string{"-bench=."}); err == nil {
+		t.Fatalf("expected -bench conflict error")
+	}
+}
+
+func TestBuildInvocationWithFuzzPattern(t *testing.T) {
+	res := locator.Resolution{
+		Mode:        locator.ModeFuzz,
+		PackageDir:  "/tmp/pkg",
+		RunPattern:  "^FuzzA$",
+		FuzzPattern: "^FuzzA$",
+	}
+	inv, err := BuildInvocation(res, []string{"-fuzztime", "10s"})
+	if err != nil {
+		t.Fatalf("BuildInvocation: %v", err)
+	}
+	want := []string{"test", "-run", "^FuzzA$", "-fuzz", "^FuzzA$", "-fuzztime", "10s", "."}
+	if !reflect.DeepEqual(inv.Args, want) {
+		t.Fatalf("args = %#v, want %#v", inv.Args, want)
+	}
+
+	if _, err := BuildInvocation(res, []string{"-fuzz=FuzzB"}); err == nil {
+		t.Fatalf("expected -fuzz conflict error")
+	}
+}
+
+func TestBuildInvocationWithTestifyPattern(t *testing.T) {
+	res := locator.Resolution{
+		Mode:           locator.ModeLeaf,
+		PackageDir:     "/tmp/pkg",
+		RunPattern:     "^TestSuite$",
+		TestifyPattern: "^TestFoo$",
+	}
+	inv, err := BuildInvocation(res, nil)
+	if err != nil {
+		t.Fatalf("BuildInvocation: %v", err)
+	}
+	want := []string{"test", "-run", "^TestSuite$", "-testify.m", "^TestFoo$", "."}
+	if !reflect.DeepEqual(inv.Args, want) {
+		t.Fatalf("args = %#v, want %#v", inv.Args, want)
+	}
+
+	if _, err := BuildInvocation(res, []string{"-testify.m=TestBar"}); err == nil {
+		t.Fatalf("expected -testify.m conflict error")
+	}
+}
+
+func TestBuildInvocationWithCheckFilter(t *testing.T) {
+	res := locator.Resolution{
+		Mode:        locator.ModeLeaf,
+		PackageDir:  "/tmp/pkg",
+		RunPattern:  "^Test$",
+		CheckFilter: "^MySuite\\.TestFoo$",
+	}
+	inv, err := BuildInvocation(res, nil)
+	if err != nil {
+		t.Fatalf("BuildInvocation: %v", err)
+	}
+	want := []string{"test", "-run", "^Test$", "-check.f", "^MySuite\\.TestFoo$", "."}
+	if !reflect.DeepEqual(inv.Args, want) {
+		t.Fatalf("args = %#v, want %#v", inv.Args, want)
+	}
+
+	if _, err := BuildInvocation(res, []string{"-check.f=TestBar"}); err == nil {
+		t.Fatalf("expected -check.f conflict error")
+	}
+}
+
+func TestBuildInvocationWithGinkgoFocus(t *testing.T) {
+	res := locator.Resolution{
+		Mode:            locator.ModeLeaf,
+		PackageDir:      "/tmp/pkg",
+		RunPattern:      "^TestBooks$",
+		GinkgoFocus:     "^Books Suite Book is a novel$",
+		GinkgoFocusFile: `books_test\.go:14`,
+	}
+	inv, err := BuildInvocation(res, nil)
+	if err != nil {
+		t.Fatalf("BuildInvocation: %v", err)
+	}
+	want := []string{"test", "-run", "^TestBooks$", "--ginkgo.focus=^Books Suite Book is a novel$", `--ginkgo.focus-file=books_test\.go:14`, "."}
+	if !reflect.DeepEqual(inv.Args, want) {
+		t.Fatalf("args = %#v, want %#v", inv.Args, want)
+	}
+
+	if _, err := BuildInvocation(res, []string{"--ginkgo.focus=novel"}); err == nil {
+		t.Fatalf("expected --ginkgo.focus conflict error")
+	}
+}
+
 func TestBuildInvocationRejectsRunOverride(t *testing.T) {
 	res := locator.Resolution{
 		Mode:       locator.ModeLeaf,
@@ -54,3 +159,84 @@ func TestBuildInvocationProject(t *testing.T) {
 		t.Fatalf("args = %#v, want %#v", inv.Args, want)
 	}
 }
+
+func TestBuildInvocationReverseDeps(t *testing.T) {
+	res := locator.Resolution{
+		Mode:       locator.ModeRdeps,
+		ModuleRoot: "/tmp/root",
+		PackageDir: "/tmp/root/base",
+		Packages:   []string{"example.com/m/base", "example.com/m/mid"},
+	}
+	inv, err := BuildInvocation(res, []string{"-v"})
+	if err != nil {
+		t.Fatalf("BuildInvocation: %v", err)
+	}
+	if inv.Dir != "/tmp/root" {
+		t.Fatalf("dir = %q, want /tmp/root", inv.Dir)
+	}
+	want := []string{"test", "-v", "example.com/m/base", "example.com/m/mid"}
+	if !reflect.DeepEqual(inv.Args, want) {
+		t.Fatalf("args = %#v, want %#v", inv.Args, want)
+	}
+}
+
+func TestBuildInvocationWithTargetPlatform(t *testing.T) {
+	res := locator.Resolution{
+		Mode:       locator.ModeTest,
+		PackageDir: "/tmp/pkg",
+		RunPattern: "^TestA$",
+		GOOS:       "windows",
+		GOARCH:     "arm64",
+	}
+	inv, err := BuildInvocation(res, nil)
+	if err != nil {
+		t.Fatalf("BuildInvocation: %v", err)
+	}
+	want := []string{"GOOS=windows", "GOARCH=arm64"}
+	if !reflect.DeepEqual(inv.Env, want) {
+		t.Fatalf("env = %#v, want %#v", inv.Env, want)
+	}
+}
+
+func TestBuildInvocationMergesInferredTags(t *testing.T) {
+	res := locator.Resolution{
+		Mode:       locator.ModeTest,
+		PackageDir: "/tmp/pkg",
+		RunPattern: "^TestA$",
+		BuildTags:  []string{"integration"},
+	}
+	cases := []struct {
+		passthrough []string
+		want        []string
+	}{
+		{passthrough: nil, want: []string{"test", "-run", "^TestA$", "-tags", "integration", "."}},
+		{passthrough: []string{"-v", "-tags=slow"}, want: []string{"test", "-run", "^TestA$", "-tags", "slow,integration", "-v", "."}},
+		{passthrough: []string{"--tags", "integration", "-count=1"}, want: []string{"test", "-run", "^TestA$", "-tags", "integration", "-count=1", "."}},
+	}
+	for _, tc := range cases {
+		inv, err := BuildInvocation(res, tc.passthrough)
+		if err != nil {
+			t.Fatalf("BuildInvocation: %v", err)
+		}
+		if !reflect.DeepEqual(inv.Args, tc.want) {
+			t.Fatalf("args(%q) = %#v, want %#v", tc.passthrough, inv.Args, tc.want)
+		}
+	}
+}
+
+func TestBuildTags(t *testing.T) {
+	cases := []struct {
+		args []string
+		want []string
+	}{
+		{args: []string{"-v"}, want: nil},
+		{args: []string{"-tags", "integration,slow", "-v"}, want: []string{"integration", "slow"}},
+		{args: []string{"--tags=e2e"}, want: []string{"e2e"}},
+		{args: []string{"-tags=a b", "-tags=c"}, want: []string{"c"}},
+	}
+	for _, tc := range cases {
+		if got := BuildTags(tc.args); !reflect.DeepEqual(got, tc.want) {
+			t.Fatalf("BuildTags(%q) = %#v, want %#v", tc.args, got, tc.want)
+		}
+	}
+}
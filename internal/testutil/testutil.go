@@ -22,6 +22,16 @@ func FixtureFile(tb testing.TB) string {
 	return filepath.Join(RepoRoot(tb), "testdata", "fixturemod", "sample", "sample_test.go")
 }
 
+func FixturePath(tb testing.TB, elem ...string) string {
+	tb.Helper()
+	return filepath.Join(append([]string{FixtureRoot(tb)}, elem...)...)
+}
+
+func TestdataPath(tb testing.TB, elem ...string) string {
+	tb.Helper()
+	return filepath.Join(append([]string{RepoRoot(tb), "testdata"}, elem...)...)
+}
+
 func FixtureRoot(tb testing.TB) string {
 	tb.Helper()
 	return filepath.Join(RepoRoot(tb), "testdata", "fixturemod")
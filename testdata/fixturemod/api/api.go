@@ -0,0 +1,10 @@
+package api
+
+type Route string
+
+const (
+	RouteUsers  = "users"
+	RouteOrders = Route("orders")
+)
+
+const Version = 2
@@ -0,0 +1,29 @@
+package calc
+
+func Add(a, b int) int {
+	return a + b // marker:calc_add
+}
+
+func Sub(a, b int) int {
+	return a - b // marker:calc_sub
+}
+
+func Div(a, b int) int {
+	return a / b // marker:calc_div
+}
+
+type Acc struct {
+	total int
+}
+
+func (a *Acc) Push(n int) {
+	a.total = Add(a.total, n) // marker:calc_push
+}
+
+func (a *Acc) Total() int {
+	return a.total // marker:calc_total
+}
+
+func Unused() int {
+	return 0 // marker:calc_unused
+}
@@ -0,0 +1,19 @@
+package calc_test
+
+import (
+	"testing"
+
+	"example.com/fixturemod/calc"
+)
+
+func TestSubExternal(t *testing.T) {
+	t.Run("external", func(t *testing.T) {
+		t.Log("RUN:" + t.Name())
+		if calc.Sub(3, 1) != 2 {
+			t.Fatal("sub")
+		}
+	})
+	t.Run("other", func(t *testing.T) {
+		t.Log("RUN:" + t.Name())
+	})
+}
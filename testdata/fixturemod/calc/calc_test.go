@@ -0,0 +1,43 @@
+package calc
+
+import "testing"
+
+var ops = map[string]func(int, int) int{"div": Div}
+
+func TestAdd(t *testing.T) {
+	t.Run("small", func(t *testing.T) {
+		t.Log("RUN:" + t.Name())
+		if Add(1, 2) != 3 {
+			t.Fatal("add")
+		}
+	})
+	t.Run("large", func(t *testing.T) {
+		t.Log("RUN:" + t.Name())
+		if Sub(5, 2) != 3 {
+			t.Fatal("sub")
+		}
+	})
+}
+
+func TestAcc(t *testing.T) {
+	t.Log("RUN:" + t.Name())
+	var a Acc
+	pushAll(&a, 1, 2)
+}
+
+func TestDiv(t *testing.T) {
+	t.Log("RUN:" + t.Name())
+	if ops["div"](6, 3) != 2 {
+		t.Fatal("div")
+	}
+}
+
+func TestAcc_Total(t *testing.T) {
+	t.Log("RUN:" + t.Name())
+}
+
+func pushAll(a *Acc, ns ...int) {
+	for _, n := range ns {
+		a.Push(n)
+	}
+}
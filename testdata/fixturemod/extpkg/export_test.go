@@ -0,0 +1,5 @@
+package extpkg
+
+const LookupName = "lookup"
+
+var LookupFunc = lookupName
@@ -0,0 +1,24 @@
+package extpkg
+
+const Greeting = "hello"
+
+type Kind int
+
+const (
+	KindPlain Kind = iota
+	KindFancy
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindPlain:
+		return "plain"
+	case KindFancy:
+		return "fancy"
+	}
+	return "unknown"
+}
+
+var Modes = []string{"fast", "slow"}
+
+func lookupName() string { return "lookup" }
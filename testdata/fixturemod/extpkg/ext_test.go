@@ -0,0 +1,25 @@
+package extpkg_test
+
+import (
+	"testing"
+
+	"example.com/fixturemod/extpkg"
+)
+
+func TestExternal(t *testing.T) {
+	t.Run(extpkg.Greeting, func(t *testing.T) {
+		t.Log("RUN:" + t.Name()) // marker:ext_const
+	})
+	t.Run(extpkg.KindFancy.String(), func(t *testing.T) {
+		t.Log("RUN:" + t.Name()) // marker:ext_stringer
+	})
+	t.Run(extpkg.Modes[1], func(t *testing.T) {
+		t.Log("RUN:" + t.Name()) // marker:ext_index
+	})
+	t.Run(extpkg.LookupName, func(t *testing.T) {
+		if extpkg.LookupFunc() != extpkg.LookupName {
+			t.Fatal("lookup mismatch")
+		}
+		t.Log("RUN:" + t.Name()) // marker:ext_export
+	})
+}
@@ -0,0 +1,24 @@
+package testkit
+
+import "testing"
+
+func Run(t *testing.T, name string, fn func(*testing.T)) {
+	t.Helper()
+	t.Run(name, fn)
+}
+
+func Prefixed(t *testing.T, name string, fn func(*testing.T)) {
+	t.Helper()
+	t.Run("case-"+name, fn)
+}
+
+type Option func(run func(string, func(*testing.T)) bool) func(string, func(*testing.T)) bool
+
+func Case(t *testing.T, name string, fn func(*testing.T), opts ...Option) {
+	t.Helper()
+	run := t.Run
+	for _, opt := range opts {
+		run = opt(run)
+	}
+	run(name, fn)
+}
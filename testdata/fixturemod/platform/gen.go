@@ -0,0 +1,5 @@
+//go:build ignore
+
+package platform
+
+const osName = "generated-name"
@@ -0,0 +1,3 @@
+package platform
+
+const osName = "windows-name"
@@ -0,0 +1,5 @@
+package platform
+
+func Name() string {
+	return osName
+}
@@ -0,0 +1,13 @@
+package platform
+
+import "testing"
+
+func TestPlatform(t *testing.T) {
+	t.Run(osName, func(t *testing.T) {
+		t.Log("RUN:" + t.Name()) // marker:platform_os
+	})
+
+	t.Run(suiteName, func(t *testing.T) {
+		t.Log("RUN:" + t.Name()) // marker:platform_suite
+	})
+}
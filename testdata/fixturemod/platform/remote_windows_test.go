@@ -0,0 +1,11 @@
+//go:build e2e
+
+package platform
+
+import "testing"
+
+func TestRemoteWindows(t *testing.T) {
+	t.Run("dial", func(t *testing.T) { // marker:platform_remote
+		_ = t
+	})
+}
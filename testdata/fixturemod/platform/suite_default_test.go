@@ -0,0 +1,5 @@
+//go:build !integration
+
+package platform
+
+const suiteName = "unit"
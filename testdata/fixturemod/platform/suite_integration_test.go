@@ -0,0 +1,11 @@
+//go:build integration
+
+package platform
+
+import "testing"
+
+const suiteName = "integration"
+
+func TestIntegrationOnly(t *testing.T) {
+	t.Log("RUN:" + t.Name()) // marker:platform_integration
+}
@@ -0,0 +1,5 @@
+package base
+
+func Greeting() string {
+	return "hello" // marker:rdeps_base
+}
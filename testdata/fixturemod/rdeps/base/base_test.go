@@ -0,0 +1,10 @@
+package base
+
+import "testing"
+
+func TestGreeting(t *testing.T) {
+	t.Log("RUN:" + t.Name())
+	if Greeting() != "hello" {
+		t.Fatal("greeting")
+	}
+}
@@ -0,0 +1,7 @@
+package mid
+
+import "example.com/fixturemod/rdeps/base"
+
+func Shout() string {
+	return base.Greeting() + "!"
+}
@@ -0,0 +1,10 @@
+package mid
+
+import "testing"
+
+func TestShout(t *testing.T) {
+	t.Log("RUN:" + t.Name())
+	if Shout() != "hello!" {
+		t.Fatal("shout")
+	}
+}
@@ -0,0 +1,7 @@
+package top
+
+import "example.com/fixturemod/rdeps/mid"
+
+func Banner() string {
+	return "[" + mid.Shout() + "]"
+}
@@ -0,0 +1,10 @@
+package top
+
+import "testing"
+
+func TestBanner(t *testing.T) {
+	t.Log("RUN:" + t.Name())
+	if Banner() != "[hello!]" {
+		t.Fatal("banner")
+	}
+}
@@ -0,0 +1,21 @@
+package sample
+
+import "testing"
+
+func BenchmarkAnswer(b *testing.B) {
+	b.Run("small", func(b *testing.B) {
+		b.Log("RUN:BenchAnswer/small") // marker:bench_small
+		for i := 0; i < b.N; i++ {
+			_ = Answer()
+		}
+	})
+	b.Run("large", func(b *testing.B) {
+		b.Run("nested", func(b *testing.B) {
+			b.Log("RUN:BenchAnswer/large/nested") // marker:bench_nested
+		})
+	})
+}
+
+func BenchmarkPlain(b *testing.B) {
+	b.Log("RUN:BenchPlain") // marker:bench_plain
+}
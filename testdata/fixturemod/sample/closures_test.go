@@ -0,0 +1,62 @@
+package sample
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestClosures(t *testing.T) {
+	func() {
+		t.Run("iife", func(t *testing.T) {
+			t.Log("RUN:" + t.Name()) // marker:closure_iife
+		})
+	}()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		t.Run("goroutine", func(t *testing.T) {
+			t.Log("RUN:" + t.Name()) // marker:closure_goroutine
+		})
+	}()
+	wg.Wait()
+
+	defer func() {
+		t.Run("deferred", func(t *testing.T) {
+			t.Log("RUN:" + t.Name()) // marker:closure_defer
+		})
+	}()
+
+	run := func(name string, want int) {
+		t.Run(name, func(t *testing.T) {
+			if got := (Calc{}).Double(want / 2); got != want {
+				t.Fatalf("Double(%d) = %d, want %d", want/2, got, want)
+			}
+			t.Log("RUN:" + t.Name()) // marker:closure_lambda
+		})
+	}
+	run("two", 2)
+	run("four", 4)
+	for _, name := range []string{"six"} {
+		run(name, 6)
+	}
+
+	check := func(t *testing.T, name string) {
+		t.Run(name, func(t *testing.T) {
+			t.Log("RUN:" + t.Name()) // marker:closure_param
+		})
+	}
+	t.Run("group", func(t *testing.T) {
+		check(t, "first")
+	})
+
+	capture := func(name string) {
+		t.Run(name, func(t *testing.T) {
+			t.Log("RUN:" + t.Name()) // marker:closure_captured
+		})
+	}
+	t.Run("outer", func(t *testing.T) {
+		capture("inner")
+	})
+}
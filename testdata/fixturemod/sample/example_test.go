@@ -0,0 +1,26 @@
+package sample_test
+
+import (
+	"fmt"
+
+	"example.com/fixturemod/sample"
+)
+
+func ExampleAnswer() {
+	fmt.Println(sample.Answer()) // marker:example_func
+	// Output: 42
+}
+
+func ExampleCalc_Double() {
+	fmt.Println(sample.Calc{}.Double(2)) // marker:example_method
+	// Output: 4
+}
+
+func Example_suffix() {
+	fmt.Println("suffix") // marker:example_suffix
+	// Unordered output: suffix
+}
+
+func ExampleAnswer_silent() {
+	fmt.Println(sample.Answer()) // marker:example_no_output
+}
@@ -0,0 +1,19 @@
+package sample
+
+import "testing"
+
+func FuzzEcho(f *testing.F) {
+	f.Add("alpha")
+	f.Add("beta") // marker:fuzz_seed
+	f.Fuzz(func(t *testing.T, s string) {
+		t.Log("RUN:FuzzEcho/" + s) // marker:fuzz_body
+	})
+}
+
+func FuzzLooped(f *testing.F) {
+	for _, s := range []string{"x", "y"} {
+		f.Add(s)
+	}
+	f.Add("z") // marker:fuzz_unordered
+	f.Fuzz(func(t *testing.T, s string) {})
+}
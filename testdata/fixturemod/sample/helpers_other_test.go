@@ -0,0 +1,7 @@
+package sample
+
+import "testing"
+
+func TestHelpersElsewhere(t *testing.T) {
+	t.Run("reuse", testCreate)
+}
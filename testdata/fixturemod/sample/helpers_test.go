@@ -0,0 +1,35 @@
+package sample
+
+import "testing"
+
+type checker struct {
+	want int
+}
+
+func TestHelpers(t *testing.T) {
+	t.Run("create", testCreate)
+	t.Run("create again", testCreate)
+	t.Run("double", makeCase(2, 4))
+	c := checker{want: 1}
+	t.Run("method", c.check)
+}
+
+func testCreate(t *testing.T) {
+	t.Log("RUN:" + t.Name()) // marker:helper_named
+	t.Run("nested", func(t *testing.T) {
+		t.Log("RUN:" + t.Name()) // marker:helper_nested
+	})
+}
+
+func makeCase(in, want int) func(*testing.T) {
+	return func(t *testing.T) {
+		if got := (Calc{}).Double(in); got != want {
+			t.Fatalf("Double(%d) = %d, want %d", in, got, want)
+		}
+		t.Log("RUN:" + t.Name()) // marker:helper_factory
+	}
+}
+
+func (c checker) check(t *testing.T) {
+	t.Log("RUN:" + t.Name()) // marker:helper_method
+}
@@ -0,0 +1,28 @@
+package sample
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestLoops(t *testing.T) {
+	for _, name := range []string{"red", "green"} {
+		t.Run(name, func(t *testing.T) {
+			t.Log("RUN:" + t.Name()) // marker:loop_strings
+		})
+	}
+
+	for i := 0; i < 3; i++ {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Log("RUN:" + t.Name()) // marker:loop_counter
+		})
+	}
+
+	for _, size := range []int{8, 16} {
+		for n := range 2 {
+			t.Run("size-"+strconv.Itoa(size)+"-"+strconv.Itoa(n), func(t *testing.T) {
+				t.Log("RUN:" + t.Name()) // marker:loop_nested
+			})
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package sample
+
+import "testing"
+
+func TestNames(t *testing.T) {
+	t.Run("adds two numbers", func(t *testing.T) {
+		t.Log("RUN:" + t.Name()) // marker:names_space
+	})
+	t.Run("bell\x07", func(t *testing.T) {
+		t.Log("RUN:" + t.Name()) // marker:names_escape
+	})
+	t.Run("dup", func(t *testing.T) {
+		t.Log("RUN:" + t.Name()) // marker:names_dup_first
+	})
+	t.Run("dup", func(t *testing.T) {
+		t.Log("RUN:" + t.Name()) // marker:names_dup_second
+	})
+	t.Run("dup", func(t *testing.T) {
+		t.Log("RUN:" + t.Name()) // marker:names_dup_third
+	})
+	t.Run("", func(t *testing.T) {
+		t.Log("RUN:" + t.Name()) // marker:names_empty
+	})
+	t.Run("in/out", func(t *testing.T) {
+		t.Run("deep/er", func(t *testing.T) {
+			t.Log("RUN:" + t.Name()) // marker:names_slash_nested
+		})
+		t.Log("RUN:" + t.Name()) // marker:names_slash_outer
+	})
+}
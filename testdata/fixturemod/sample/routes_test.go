@@ -0,0 +1,22 @@
+package sample
+
+import (
+	"fmt"
+	"testing"
+
+	"example.com/fixturemod/api"
+)
+
+func TestRoutes(t *testing.T) {
+	t.Run(api.RouteUsers, func(t *testing.T) {
+		t.Log("RUN:" + t.Name()) // marker:route_users
+	})
+
+	t.Run(string(api.RouteOrders), func(t *testing.T) {
+		t.Log("RUN:" + t.Name()) // marker:route_orders
+	})
+
+	t.Run(fmt.Sprintf("v%d", api.Version), func(t *testing.T) {
+		t.Log("RUN:" + t.Name()) // marker:route_version
+	})
+}
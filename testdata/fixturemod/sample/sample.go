@@ -3,3 +3,9 @@ package sample
 func Answer() int {
 	return 42
 }
+
+type Calc struct{}
+
+func (Calc) Double(x int) int {
+	return x * 2
+}
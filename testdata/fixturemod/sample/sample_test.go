@@ -16,7 +16,13 @@ func TestAlpha(t *testing.T) {
 			t.Log("RUN:Alpha/outer/inner") // marker:inner
 		})
 
-		dynamicName := "dyn"
+		localName := "local"
+		t.Run(localName, func(t *testing.T) {
+			t.Log("RUN:Alpha/local") // marker:local_var
+		})
+
+		dynamicName := "dy"
+		appendName(&dynamicName, "n")
 		t.Run(dynamicName, func(t *testing.T) {
 			t.Log("RUN:Alpha/dyn") // marker:dynamic
 		})
@@ -35,6 +41,27 @@ func TestConst(t *testing.T) {
 	t.Run(strconv.Itoa(11), func(t *testing.T) {
 		t.Log("RUN:Const/11") // marker:itoa
 	})
+
+	chained := "chain"
+	chained += "-" + suffixConst
+	t.Run(chained, func(t *testing.T) {
+		t.Log("RUN:Const/chain-const-sub") // marker:plus_assign
+	})
+
+	id := 3
+	t.Run(fmt.Sprintf("id-%d", id), func(t *testing.T) {
+		t.Log("RUN:Const/id-3") // marker:local_int
+	})
+
+	reassigned := "before"
+	reassigned = "after"
+	t.Run(reassigned, func(t *testing.T) {
+		t.Log("RUN:Const/after") // marker:reassigned
+	})
+}
+
+func appendName(name *string, suffix string) {
+	*name += suffix
 }
 
 func TestNoSub(t *testing.T) {
@@ -0,0 +1,18 @@
+package sample
+
+import (
+	"strconv"
+	"strings"
+)
+
+type Shade int
+
+func (s Shade) String() string {
+	return "shade-" + strconv.Itoa(int(s))
+}
+
+type Tone string
+
+func (t Tone) String() string {
+	return strings.ToUpper(string(t))
+}
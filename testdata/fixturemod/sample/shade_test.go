@@ -0,0 +1,15 @@
+package sample
+
+import (
+	str "strings"
+	"testing"
+)
+
+func TestShades(t *testing.T) {
+	t.Run(Shade(3).String(), func(t *testing.T) {
+		t.Log("RUN:" + t.Name()) // marker:shade_method
+	})
+	t.Run(Tone("warm").String(), func(t *testing.T) {
+		t.Log("RUN:" + str.ToLower(t.Name())) // marker:shade_alias
+	})
+}
@@ -0,0 +1,67 @@
+package sample
+
+import "testing"
+
+type tableCase struct {
+	name string
+	want int
+}
+
+func TestTable(t *testing.T) {
+	tests := []tableCase{
+		{name: "first", want: 1},
+		{
+			name: "second", // marker:table_keyed
+			want: 2,
+		},
+		{"third", 3}, // marker:table_positional
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Log("RUN:Table/" + tc.name) // marker:table_body
+		})
+	}
+}
+
+func TestInlineTable(t *testing.T) {
+	for _, tc := range []struct {
+		label string
+	}{
+		{label: "only"}, // marker:table_inline
+	} {
+		t.Run(tc.label, func(t *testing.T) {
+			t.Log("RUN:InlineTable/" + tc.label)
+		})
+	}
+}
+
+var packageCases = map[string]tableCase{
+	"pkg-one": {want: 1}, // marker:map_package
+	"pkg-two": {want: 2},
+}
+
+func TestMapTable(t *testing.T) {
+	for name, tc := range map[string]tableCase{
+		"empty": {want: 0}, // marker:map_inline
+		"full":  {want: 1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Logf("RUN:MapTable/%s %d", name, tc.want) // marker:map_body
+		})
+	}
+
+	local := map[string]int{
+		"local-" + "key": 1, // marker:map_local
+	}
+	for name := range local {
+		t.Run(name, func(t *testing.T) {
+			t.Log("RUN:MapTable/" + name)
+		})
+	}
+
+	for name := range packageCases {
+		t.Run(name, func(t *testing.T) {
+			t.Log("RUN:MapTable/" + name)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package sample
+
+import "testing"
+
+type harness struct {
+	t *testing.T
+}
+
+type embedded struct {
+	*testing.T
+}
+
+func TestTyped(t *testing.T) {
+	tt := t
+	tt.Run("alias", func(t *testing.T) {
+		t.Log("RUN:" + t.Name()) // marker:typed_alias
+	})
+
+	h := harness{t: t}
+	h.t.Run("field", func(t *testing.T) {
+		t.Log("RUN:" + t.Name()) // marker:typed_field
+	})
+
+	e := embedded{t}
+	e.Run("embedded", func(t *testing.T) {
+		t.Log("RUN:" + t.Name()) // marker:typed_embedded
+	})
+
+	var tb testing.TB = t
+	tb.(*testing.T).Run("asserted", func(t *testing.T) {
+		t.Log("RUN:" + t.Name()) // marker:typed_assert
+	})
+
+	runWrapped(t, func(t *testing.T) {
+		t.Run("hidden", func(t *testing.T) {
+			t.Log("RUN:" + t.Name()) // marker:typed_shadowed
+		})
+	})
+
+	runOpaque(t, func(t *testing.T) {
+		t.Run("opaque", func(t *testing.T) {
+			t.Log("RUN:" + t.Name()) // marker:typed_unknown
+		})
+	})
+}
+
+func runWrapped(t *testing.T, fn func(*testing.T)) {
+	t.Run("wrapped", fn)
+}
+
+var runOpaque = func(t *testing.T, fn func(*testing.T)) {
+	t.Run("unwrapped", fn)
+}
+
+func TestOuter(t *testing.T) {
+	t.Run("a", func(t2 *testing.T) {
+		t.Run("b", func(t *testing.T) {
+			t.Log("RUN:" + t.Name()) // marker:typed_outer
+		})
+	})
+}
@@ -0,0 +1,60 @@
+package sample
+
+import (
+	"testing"
+
+	"example.com/fixturemod/internal/testkit"
+)
+
+func runCase(t *testing.T, name string, fn func(*testing.T)) {
+	t.Helper()
+	t.Run(name, fn)
+}
+
+func dyn(t *testing.T, name string, fn func(*testing.T)) {
+	t.Helper()
+	run := t.Run
+	run(name, fn)
+}
+
+type cases struct {
+	t *testing.T
+}
+
+func (c cases) Add(name string, fn func(*testing.T)) {
+	c.t.Helper()
+	run := c.t.Run
+	run(name, fn)
+}
+
+func TestWrappers(t *testing.T) {
+	runCase(t, "timeout", func(t *testing.T) {
+		t.Log("RUN:" + t.Name()) // marker:wrap_timeout
+	})
+
+	runCase(t, "retry", func(t *testing.T) {
+		runCase(t, "inner", func(t *testing.T) {
+			t.Log("RUN:" + t.Name()) // marker:wrap_nested
+		})
+	})
+
+	testkit.Run(t, "remote", func(t *testing.T) {
+		t.Log("RUN:" + t.Name()) // marker:wrap_remote
+	})
+
+	testkit.Prefixed(t, "slow", func(t *testing.T) {
+		t.Log("RUN:" + t.Name()) // marker:wrap_prefixed
+	})
+
+	testkit.Case(t, "configured", func(t *testing.T) {
+		t.Log("RUN:" + t.Name()) // marker:wrap_configured
+	})
+
+	dyn(t, "alpha", func(t *testing.T) {
+		t.Log("RUN:" + t.Name()) // marker:wrap_local
+	})
+
+	cases{t: t}.Add("beta", func(t *testing.T) {
+		t.Log("RUN:" + t.Name()) // marker:wrap_method
+	})
+}
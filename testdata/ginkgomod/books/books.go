@@ -0,0 +1,13 @@
+package books
+
+type Book struct {
+	Title string
+	Pages int
+}
+
+func (b Book) Category() string {
+	if b.Pages > 300 {
+		return "NOVEL"
+	}
+	return "SHORT STORY"
+}
@@ -0,0 +1,40 @@
+package books_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"example.com/ginkgomod/books"
+)
+
+var _ = Describe("Book", func() {
+	Context("with more than 300 pages", func() {
+		It("is a novel", func() {
+			GinkgoWriter.Println("RUN:Book/novel") // marker:novel
+			Expect(books.Book{Pages: 400}.Category()).To(Equal("NOVEL"))
+		})
+	})
+
+	Context("with fewer than 300 pages", func() {
+		It("is a short story", func() {
+			GinkgoWriter.Println("RUN:Book/short") // marker:short
+			Expect(books.Book{Pages: 20}.Category()).To(Equal("SHORT STORY"))
+		})
+
+		for _, pages := range []int{1, 2} {
+			It(fmt.Sprint("handles ", pages, " pages"), func() {
+				Expect(books.Book{Pages: pages}.Category()).To(Equal("SHORT STORY")) // marker:dynamic_it
+			})
+		}
+	})
+
+	DescribeTable("categories",
+		func(pages int, want string) {
+			Expect(books.Book{Pages: pages}.Category()).To(Equal(want)) // marker:table_body
+		},
+		Entry("tiny", 10, "SHORT STORY"), // marker:table_entry
+		Entry("huge", 1000, "NOVEL"),
+	)
+})
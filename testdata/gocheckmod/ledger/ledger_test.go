@@ -0,0 +1,27 @@
+package ledger
+
+import (
+	"testing"
+
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) {
+	TestingT(t) // marker:entry
+}
+
+type LedgerSuite struct{}
+
+var _ = Suite(&LedgerSuite{})
+
+func (s *LedgerSuite) SetUpTest(c *C) {
+	c.Log("setup") // marker:setup
+}
+
+func (s *LedgerSuite) TestEmpty(c *C) {
+	c.Assert(Total(), Equals, 0) // marker:empty
+}
+
+func (s *LedgerSuite) TestSum(c *C) {
+	c.Assert(Total(1, 2), Equals, 3) // marker:sum
+}
@@ -0,0 +1,23 @@
+package shapes
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestArea(t *testing.T) {
+	c := qt.New(t)
+	c.Run("square", func(c *qt.C) {
+		c.Run("unit", func(c *qt.C) {
+			c.Assert(Area(1, 1), qt.Equals, 1) // marker:unit
+		})
+		c.Assert(Area(2, 2), qt.Equals, 4) // marker:square
+	})
+}
+
+func TestAreaDirect(t *testing.T) {
+	qt.New(t).Run("rect", func(c *qt.C) {
+		c.Assert(Area(2, 3), qt.Equals, 6) // marker:rect
+	})
+}
@@ -0,0 +1,5 @@
+package accounts
+
+func Balance() int {
+	return 100
+}
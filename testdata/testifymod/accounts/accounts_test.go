@@ -0,0 +1,30 @@
+package accounts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type AccountSuite struct {
+	suite.Suite
+	balance int
+}
+
+func (s *AccountSuite) SetupTest() {
+	s.balance = Balance() // marker:setup
+}
+
+func (s *AccountSuite) TestDeposit() {
+	s.Equal(100, s.balance) // marker:deposit
+}
+
+func (s *AccountSuite) TestWithdraw() {
+	s.Run("overdraft", func() {
+		s.T().Log("RUN:AccountSuite/TestWithdraw/overdraft") // marker:overdraft
+	})
+}
+
+func TestAccountSuite(t *testing.T) {
+	suite.Run(t, new(AccountSuite)) // marker:entry
+}
@@ -0,0 +1,16 @@
+package mixed
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	check "gopkg.in/check.v1"
+)
+
+func TestTestify(t *testing.T) {
+	suite.Run(t, new(TallySuite))
+}
+
+func TestCheck(t *testing.T) {
+	check.TestingT(t)
+}
@@ -0,0 +1,22 @@
+package mixed
+
+import (
+	"github.com/stretchr/testify/suite"
+	check "gopkg.in/check.v1"
+)
+
+type TallySuite struct {
+	suite.Suite
+}
+
+func (s *TallySuite) TestAdd() {
+	s.Equal(3, 1+2)
+}
+
+type CountSuite struct{}
+
+var _ = check.Suite(&CountSuite{})
+
+func (s *CountSuite) TestSum(c *check.C) {
+	c.Assert(1+2, check.Equals, 3)
+}